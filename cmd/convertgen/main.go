@@ -0,0 +1,417 @@
+// Command convertgen generates reflection-free functions for converting one struct type into another. The generated
+// functions can be passed directly to convert.Slice and convert.Map. It is intended to be invoked via go generate from
+// the package that declares both types:
+//
+//	//go:generate go run github.com/davidsbond/x/cmd/convertgen -from UserDTO -to User
+//
+// Fields are matched by name, or by the name given in their struct tag (the "convert" tag by default). Fields tagged
+// with "-" are ignored. Generation fails if any field of either type is left unmapped. The generated file also
+// contains compile-time assertions on the shape of both types, so adding or removing fields without regenerating
+// causes a compilation error rather than a silently incomplete conversion.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type (
+	config struct {
+		From   string
+		To     string
+		Func   string
+		Tag    string
+		Output string
+	}
+
+	structType struct {
+		name    string
+		spec    *ast.StructType
+		file    *ast.File
+		pkgName string
+	}
+
+	field struct {
+		name string
+		key  string
+		typ  ast.Expr
+	}
+)
+
+func main() {
+	var cfg config
+
+	flag.StringVar(&cfg.From, "from", "", "The name of the struct type to convert from")
+	flag.StringVar(&cfg.To, "to", "", "The name of the struct type to convert to")
+	flag.StringVar(&cfg.Func, "func", "", "The name of the generated function, defaults to <from>To<to>")
+	flag.StringVar(&cfg.Tag, "tag", "convert", "The struct tag used to match fields by name")
+	flag.StringVar(&cfg.Output, "output", "", "The file to write, defaults to <from>_to_<to>.go")
+	flag.Parse()
+
+	if err := run(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "convertgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(cfg config) error {
+	if cfg.From == "" || cfg.To == "" {
+		return errors.New("both -from and -to must be specified")
+	}
+
+	if cfg.Output == "" {
+		cfg.Output = strings.ToLower(cfg.From + "_to_" + cfg.To + ".go")
+	}
+
+	out, err := generate(".", cfg)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(cfg.Output, out, 0o644)
+}
+
+// generate parses the Go package within dir and returns the formatted source of a file containing the conversion
+// function described by cfg.
+func generate(dir string, cfg config) ([]byte, error) {
+	if cfg.Func == "" {
+		cfg.Func = cfg.From + "To" + cfg.To
+	}
+
+	if cfg.Tag == "" {
+		cfg.Tag = "convert"
+	}
+
+	fset := token.NewFileSet()
+	files, err := parseDir(fset, dir, filepath.Base(cfg.Output))
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := findStruct(files, cfg.From)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := findStruct(files, cfg.To)
+	if err != nil {
+		return nil, err
+	}
+
+	fromFields, err := fields(from, cfg.Tag)
+	if err != nil {
+		return nil, err
+	}
+
+	toFields, err := fields(to, cfg.Tag)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := match(from, fromFields, to, toFields)
+	if err != nil {
+		return nil, err
+	}
+
+	imports := make(map[string]string)
+	collectImports(from, imports)
+	collectImports(to, imports)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by convertgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", from.pkgName)
+
+	if len(imports) > 0 {
+		buf.WriteString("import (\n")
+		for _, path := range slices.Sorted(maps.Keys(imports)) {
+			if name := imports[path]; name != "" {
+				fmt.Fprintf(&buf, "%s ", name)
+			}
+
+			fmt.Fprintf(&buf, "%s\n", strconv.Quote(path))
+		}
+		buf.WriteString(")\n\n")
+	}
+
+	fmt.Fprintf(&buf, "// %s converts a %s into a %s. It can be used with convert.Slice.\n", cfg.Func, cfg.From, cfg.To)
+	fmt.Fprintf(&buf, "func %s(in %s) %s {\n", cfg.Func, cfg.From, cfg.To)
+	fmt.Fprintf(&buf, "return %s{\n", cfg.To)
+	for _, a := range assignments {
+		fmt.Fprintf(&buf, "%s: %s,\n", a[0], a[1])
+	}
+	buf.WriteString("}\n}\n\n")
+
+	fmt.Fprintf(&buf, "// %sEntry converts a %s into a %s, ignoring the given key. It can be used with convert.Map.\n", cfg.Func, cfg.From, cfg.To)
+	fmt.Fprintf(&buf, "func %sEntry[K comparable](_ K, in %s) %s {\n", cfg.Func, cfg.From, cfg.To)
+	fmt.Fprintf(&buf, "return %s(in)\n}\n\n", cfg.Func)
+
+	fmt.Fprintf(&buf, "// These conversions fail to compile if the fields of %s or %s change without regenerating this file.\n", cfg.From, cfg.To)
+	fmt.Fprintf(&buf, "var (\n")
+	fmt.Fprintf(&buf, "_ = %s(%s{})\n", cfg.From, exprString(from.spec))
+	fmt.Fprintf(&buf, "_ = %s(%s{})\n", cfg.To, exprString(to.spec))
+	fmt.Fprintf(&buf, ")\n")
+
+	return format.Source(buf.Bytes())
+}
+
+func parseDir(fset *token.FileSet, dir string, exclude string) ([]*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*ast.File, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") || name == exclude {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+func findStruct(files []*ast.File, name string) (structType, error) {
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if ts.Name.Name != name {
+					continue
+				}
+
+				if ts.TypeParams != nil {
+					return structType{}, fmt.Errorf("type %s: generic types are not supported", name)
+				}
+
+				st, ok := ts.Type.(*ast.StructType)
+				if !ok {
+					return structType{}, fmt.Errorf("type %s: not a struct", name)
+				}
+
+				return structType{
+					name:    name,
+					spec:    st,
+					file:    file,
+					pkgName: file.Name.Name,
+				}, nil
+			}
+		}
+	}
+
+	return structType{}, fmt.Errorf("type %s: not found", name)
+}
+
+func fields(st structType, tag string) ([]field, error) {
+	out := make([]field, 0, len(st.spec.Fields.List))
+	seen := make(map[string]string)
+
+	for _, f := range st.spec.Fields.List {
+		key := ""
+		if f.Tag != nil {
+			raw, err := strconv.Unquote(f.Tag.Value)
+			if err != nil {
+				return nil, fmt.Errorf("type %s: invalid struct tag %s: %w", st.name, f.Tag.Value, err)
+			}
+
+			value, _, _ := strings.Cut(reflect.StructTag(raw).Get(tag), ",")
+			if value == "-" {
+				continue
+			}
+
+			key = value
+		}
+
+		names := make([]string, 0, len(f.Names))
+		for _, n := range f.Names {
+			names = append(names, n.Name)
+		}
+
+		if len(names) == 0 {
+			names = append(names, embeddedName(f.Type))
+		}
+
+		for _, name := range names {
+			k := key
+			if k == "" {
+				k = name
+			}
+
+			if other, ok := seen[k]; ok {
+				return nil, fmt.Errorf("type %s: fields %s and %s both map to %q", st.name, other, name, k)
+			}
+
+			seen[k] = name
+			out = append(out, field{name: name, key: k, typ: f.Type})
+		}
+	}
+
+	return out, nil
+}
+
+func match(from structType, fromFields []field, to structType, toFields []field) ([][2]string, error) {
+	sources := make(map[string]field, len(fromFields))
+	for _, f := range fromFields {
+		sources[f.key] = f
+	}
+
+	var (
+		assignments [][2]string
+		unmapped    []string
+	)
+
+	used := make(map[string]bool, len(fromFields))
+	for _, dst := range toFields {
+		src, ok := sources[dst.key]
+		if !ok {
+			unmapped = append(unmapped, to.name+"."+dst.name)
+			continue
+		}
+
+		used[src.key] = true
+
+		value := "in." + src.name
+		if dstType := exprString(dst.typ); dstType != exprString(src.typ) {
+			value = conversion(dst.typ, dstType, value)
+		}
+
+		assignments = append(assignments, [2]string{dst.name, value})
+	}
+
+	for _, src := range fromFields {
+		if !used[src.key] {
+			unmapped = append(unmapped, from.name+"."+src.name)
+		}
+	}
+
+	if len(unmapped) > 0 {
+		return nil, fmt.Errorf("unmapped fields: %s", strings.Join(unmapped, ", "))
+	}
+
+	return assignments, nil
+}
+
+// conversion returns an expression converting value into the given type. Types that would be ambiguous when used
+// directly as a conversion are wrapped in parentheses.
+func conversion(typ ast.Expr, typeString string, value string) string {
+	switch typ.(type) {
+	case *ast.StarExpr, *ast.FuncType, *ast.ChanType:
+		return "(" + typeString + ")(" + value + ")"
+	default:
+		return typeString + "(" + value + ")"
+	}
+}
+
+func embeddedName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(e.X)
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.IndexExpr:
+		return embeddedName(e.X)
+	case *ast.IndexListExpr:
+		return embeddedName(e.X)
+	case *ast.Ident:
+		return e.Name
+	default:
+		return ""
+	}
+}
+
+var versionSuffix = regexp.MustCompile(`^v[0-9]+$`)
+
+// collectImports adds the import paths of any packages referenced by the struct's fields to the provided map, keyed
+// by path with the explicit import name as the value.
+func collectImports(st structType, imports map[string]string) {
+	used := make(map[string]bool)
+	ast.Inspect(st.spec, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		if ident, ok := sel.X.(*ast.Ident); ok {
+			used[ident.Name] = true
+		}
+
+		return false
+	})
+
+	for _, spec := range st.file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		name := ""
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+
+		if used[name] || (name == "" && used[defaultImportName(path)]) {
+			imports[path] = name
+		}
+	}
+}
+
+func defaultImportName(path string) string {
+	elems := strings.Split(path, "/")
+	name := elems[len(elems)-1]
+	if versionSuffix.MatchString(name) && len(elems) > 1 {
+		name = elems[len(elems)-2]
+	}
+
+	name, _, _ = strings.Cut(name, ".")
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+func exprString(expr ast.Expr) string {
+	var buf bytes.Buffer
+	// Printing to a bytes.Buffer cannot fail.
+	_ = printer.Fprint(&buf, token.NewFileSet(), stripTags(expr))
+	return buf.String()
+}
+
+// stripTags removes struct tags and comments from any struct types within expr, as they do not affect conversion
+// between struct types and would otherwise be duplicated into generated code.
+func stripTags(expr ast.Expr) ast.Expr {
+	st, ok := expr.(*ast.StructType)
+	if !ok {
+		return expr
+	}
+
+	list := make([]*ast.Field, len(st.Fields.List))
+	for i, f := range st.Fields.List {
+		list[i] = &ast.Field{Names: f.Names, Type: stripTags(f.Type)}
+	}
+
+	return &ast.StructType{Fields: &ast.FieldList{List: list}}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"flag"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "Update golden files")
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Name          string
+		Dir           string
+		Config        config
+		ExpectedError string
+	}{
+		{
+			Name: "generates conversion",
+			Dir:  "basic",
+			Config: config{
+				From:   "UserDTO",
+				To:     "User",
+				Output: "generated.go",
+			},
+		},
+		{
+			Name: "generates conversion with custom name",
+			Dir:  "basic",
+			Config: config{
+				From:   "UserDTO",
+				To:     "User",
+				Func:   "userFromDTO",
+				Output: "generated_func.go",
+			},
+		},
+		{
+			Name: "fails on unmapped fields",
+			Dir:  "unmapped",
+			Config: config{
+				From: "UserDTO",
+				To:   "User",
+			},
+			ExpectedError: "unmapped fields: User.Name, UserDTO.Nickname",
+		},
+		{
+			Name: "fails on missing type",
+			Dir:  "unmapped",
+			Config: config{
+				From: "Missing",
+				To:   "User",
+			},
+			ExpectedError: "type Missing: not found",
+		},
+		{
+			Name: "fails on non-struct type",
+			Dir:  "unmapped",
+			Config: config{
+				From: "Name",
+				To:   "User",
+			},
+			ExpectedError: "type Name: not a struct",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			dir := filepath.Join("testdata", tc.Dir)
+
+			actual, err := generate(dir, tc.Config)
+			if tc.ExpectedError != "" {
+				require.EqualError(t, err, tc.ExpectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			typeCheck(t, dir, actual)
+
+			golden := filepath.Join(dir, tc.Config.Output+".golden")
+			if *update {
+				require.NoError(t, os.WriteFile(golden, actual, 0o644))
+			}
+
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(actual))
+		})
+	}
+}
+
+// typeCheck ensures that the generated source compiles alongside the package it was generated from.
+func typeCheck(t *testing.T, dir string, generated []byte) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	files, err := parseDir(fset, dir, "")
+	require.NoError(t, err)
+
+	file, err := parser.ParseFile(fset, "generated.go", generated, 0)
+	require.NoError(t, err)
+
+	cfg := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = cfg.Check(file.Name.Name, fset, append([]*ast.File{file}, files...), nil)
+	require.NoError(t, err)
+}
@@ -0,0 +1,30 @@
+package basic
+
+import (
+	"time"
+
+	stdurl "net/url"
+)
+
+type (
+	UserDTO struct {
+		ID        string `convert:"Identifier"`
+		Name      string
+		Age       int64
+		Email     *string
+		Website   *stdurl.URL
+		CreatedAt time.Time
+		Internal  bool `convert:"-"`
+	}
+
+	Age int
+
+	User struct {
+		Identifier string
+		Name       string
+		Age        Age
+		Email      *string
+		Website    *stdurl.URL
+		CreatedAt  time.Time
+	}
+)
@@ -0,0 +1,15 @@
+package unmapped
+
+type (
+	UserDTO struct {
+		ID       string
+		Nickname string
+	}
+
+	User struct {
+		ID   string
+		Name string
+	}
+
+	Name string
+)
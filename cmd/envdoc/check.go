@@ -0,0 +1,124 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/davidsbond/x/envvar"
+)
+
+// knownTypes maps type expressions to the types envvar.Load can parse. Variables with types not present here are only
+// checked for being set when required.
+var knownTypes = map[string]reflect.Type{
+	"string":            reflect.TypeFor[string](),
+	"bool":              reflect.TypeFor[bool](),
+	"int":               reflect.TypeFor[int](),
+	"int8":              reflect.TypeFor[int8](),
+	"int16":             reflect.TypeFor[int16](),
+	"int32":             reflect.TypeFor[int32](),
+	"int64":             reflect.TypeFor[int64](),
+	"uint":              reflect.TypeFor[uint](),
+	"uint8":             reflect.TypeFor[uint8](),
+	"uint16":            reflect.TypeFor[uint16](),
+	"uint32":            reflect.TypeFor[uint32](),
+	"uint64":            reflect.TypeFor[uint64](),
+	"float32":           reflect.TypeFor[float32](),
+	"float64":           reflect.TypeFor[float64](),
+	"byte":              reflect.TypeFor[byte](),
+	"rune":              reflect.TypeFor[rune](),
+	"time.Duration":     reflect.TypeFor[time.Duration](),
+	"time.Time":         reflect.TypeFor[time.Time](),
+	"*url.URL":          reflect.TypeFor[*url.URL](),
+	"netip.Addr":        reflect.TypeFor[netip.Addr](),
+	"netip.Prefix":      reflect.TypeFor[netip.Prefix](),
+	"slog.Level":        reflect.TypeFor[slog.Level](),
+	"*regexp.Regexp":    reflect.TypeFor[*regexp.Regexp](),
+	"envvar.ByteSize":   reflect.TypeFor[envvar.ByteSize](),
+	"map[string]string": reflect.TypeFor[map[string]string](),
+}
+
+// validate checks the environment variables read from the given source against their descriptions, returning a
+// description of each problem found. Validation is performed by envvar.Load, so that the rules applied match those
+// used by the application at runtime.
+func validate(vars []variable, source envvar.Source) []string {
+	fields := make([]reflect.StructField, len(vars))
+	for i, v := range vars {
+		fields[i] = reflect.StructField{
+			Name: "Field" + strconv.Itoa(i),
+			Type: typeOf(v.underlying),
+			Tag:  v.tag(),
+		}
+	}
+
+	cfg := reflect.New(reflect.StructOf(fields))
+	err := envvar.Load(cfg.Interface(), envvar.From(source))
+	if err == nil {
+		return nil
+	}
+
+	var joined interface{ Unwrap() []error }
+	if !errors.As(err, &joined) {
+		return []string{err.Error()}
+	}
+
+	errs := joined.Unwrap()
+	problems := make([]string, len(errs))
+	for i, err := range errs {
+		problems[i] = err.Error()
+	}
+
+	return problems
+}
+
+// typeOf returns the type used to validate a variable with the given type expression. Unknown types are validated as
+// strings, so that only their presence is checked.
+func typeOf(typ string) reflect.Type {
+	if elem, ok := strings.CutPrefix(typ, "[]"); ok {
+		if rt, ok := knownTypes[elem]; ok {
+			return reflect.SliceOf(rt)
+		}
+	}
+
+	if rt, ok := knownTypes[typ]; ok {
+		return rt
+	}
+
+	return knownTypes["string"]
+}
+
+// tag returns the struct tag used to validate the variable via envvar.Load.
+func (v variable) tag() reflect.StructTag {
+	env := v.Name
+	if v.Required {
+		env += ",required"
+	}
+
+	if v.empty {
+		env += ",allowempty"
+	}
+
+	if v.Sensitive {
+		env += ",sensitive"
+	}
+
+	tags := []string{fmt.Sprintf("env:%q", env)}
+	if v.hasDefault {
+		tags = append(tags, fmt.Sprintf("default:%q", v.Default))
+	}
+
+	for name, value := range map[string]string{"sep": v.sep, "layout": v.layout, "enum": v.enum} {
+		if value != "" {
+			tags = append(tags, fmt.Sprintf("%s:%q", name, value))
+		}
+	}
+
+	return reflect.StructTag(strings.Join(tags, " "))
+}
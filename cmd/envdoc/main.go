@@ -0,0 +1,344 @@
+// Command envdoc documents and validates configuration structs tagged for use with envvar.Load. It parses the Go
+// package in the current directory, finds the named struct type and outputs a table of every environment variable it
+// reads, including its type, default value, whether it is required and the field's documentation:
+//
+//	envdoc -type Config -format markdown > CONFIG.md
+//
+// When the -check flag is provided, the current environment is instead validated against the struct. Every variable
+// that is required but not set, or that cannot be parsed as its type, is reported and the command exits with a
+// non-zero status.
+//
+// Nested structs are followed when they are declared within the same package. Named types are resolved to their
+// underlying type when declared within the same package, other types are validated only if they are supported
+// directly by envvar.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/davidsbond/x/envvar"
+)
+
+type (
+	variable struct {
+		Name        string `json:"name"`
+		Type        string `json:"type"`
+		Default     string `json:"default,omitempty"`
+		Required    bool   `json:"required"`
+		Sensitive   bool   `json:"sensitive,omitempty"`
+		Description string `json:"description,omitempty"`
+
+		underlying string
+		layout     string
+		sep        string
+		enum       string
+		empty      bool
+		hasDefault bool
+	}
+
+	pkg struct {
+		types map[string]*ast.TypeSpec
+	}
+)
+
+func main() {
+	var (
+		typeName string
+		dir      string
+		format   string
+		check    bool
+	)
+
+	flag.StringVar(&typeName, "type", "", "The name of the configuration struct type")
+	flag.StringVar(&dir, "dir", ".", "The directory containing the Go package that declares the type")
+	flag.StringVar(&format, "format", "markdown", "The output format, either markdown or json")
+	flag.BoolVar(&check, "check", false, "Validate the current environment rather than outputting documentation")
+	flag.Parse()
+
+	if err := run(os.Stdout, typeName, dir, format, check); err != nil {
+		fmt.Fprintf(os.Stderr, "envdoc: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(w io.Writer, typeName string, dir string, format string, check bool) error {
+	if typeName == "" {
+		return errors.New("-type must be specified")
+	}
+
+	vars, err := describe(dir, typeName)
+	if err != nil {
+		return err
+	}
+
+	if check {
+		problems := validate(vars, envvar.OS)
+		for _, problem := range problems {
+			fmt.Fprintln(w, problem)
+		}
+
+		if len(problems) > 0 {
+			return fmt.Errorf("found %d problem(s)", len(problems))
+		}
+
+		return nil
+	}
+
+	switch format {
+	case "markdown":
+		return writeMarkdown(w, vars)
+	case "json":
+		return writeJSON(w, vars)
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// describe parses the Go package within dir and returns every environment variable read by the named struct type.
+func describe(dir string, typeName string) ([]variable, error) {
+	p, err := parseDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, ok := p.types[typeName]
+	if !ok {
+		return nil, fmt.Errorf("type %s: not found", typeName)
+	}
+
+	st, ok := spec.Type.(*ast.StructType)
+	if !ok {
+		return nil, fmt.Errorf("type %s: not a struct", typeName)
+	}
+
+	return p.variables(st, "")
+}
+
+func parseDir(dir string) (*pkg, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &pkg{types: make(map[string]*ast.TypeSpec)}
+
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				p.types[ts.Name.Name] = ts
+			}
+		}
+	}
+
+	return p, nil
+}
+
+func (p *pkg) variables(st *ast.StructType, prefix string) ([]variable, error) {
+	var vars []variable
+
+	for _, field := range st.Fields.List {
+		tag := reflect.StructTag("")
+		if field.Tag != nil {
+			raw, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid struct tag %s: %w", field.Tag.Value, err)
+			}
+
+			tag = reflect.StructTag(raw)
+		}
+
+		env, ok := tag.Lookup("env")
+		if !ok {
+			typ := field.Type
+			if star, ok := typ.(*ast.StarExpr); ok {
+				// Pointers to nested structs are only loaded by envvar.Load when they have a prefix tag.
+				if _, ok := tag.Lookup("prefix"); !ok || len(field.Names) == 0 {
+					continue
+				}
+
+				typ = star.X
+			}
+
+			nested, ok := p.structType(typ)
+			if !ok {
+				continue
+			}
+
+			nestedPrefix := prefix
+			if len(field.Names) > 0 {
+				nestedPrefix += tag.Get("prefix")
+			}
+
+			nestedVars, err := p.variables(nested, nestedPrefix)
+			if err != nil {
+				return nil, err
+			}
+
+			vars = append(vars, nestedVars...)
+			continue
+		}
+
+		name, flags, _ := strings.Cut(env, ",")
+		defaultValue, hasDefault := tag.Lookup("default")
+		vars = append(vars, variable{
+			Name:        prefix + name,
+			Type:        exprString(field.Type),
+			underlying:  p.underlying(exprString(field.Type)),
+			Default:     defaultValue,
+			Required:    hasFlag(flags, "required"),
+			Sensitive:   hasFlag(flags, "sensitive"),
+			Description: description(field),
+			layout:      tag.Get("layout"),
+			sep:         tag.Get("sep"),
+			enum:        tag.Get("enum"),
+			empty:       hasFlag(flags, "allowempty"),
+			hasDefault:  hasDefault,
+		})
+	}
+
+	return vars, nil
+}
+
+// structType returns the struct type for expr if it refers to a struct declared within the package.
+func (p *pkg) structType(expr ast.Expr) (*ast.StructType, bool) {
+	switch e := expr.(type) {
+	case *ast.StructType:
+		return e, true
+	case *ast.Ident:
+		spec, ok := p.types[e.Name]
+		if !ok {
+			return nil, false
+		}
+
+		st, ok := spec.Type.(*ast.StructType)
+		return st, ok
+	default:
+		return nil, false
+	}
+}
+
+// underlying resolves named types declared within the package to the expression of their underlying type. Struct
+// types are not resolved, as they may implement encoding.TextUnmarshaler, which cannot be detected from source.
+func (p *pkg) underlying(typ string) string {
+	if elem, ok := strings.CutPrefix(typ, "[]"); ok {
+		return "[]" + p.underlying(elem)
+	}
+
+	seen := make(map[string]bool)
+	for !seen[typ] {
+		seen[typ] = true
+
+		spec, ok := p.types[typ]
+		if !ok {
+			return typ
+		}
+
+		if _, ok = spec.Type.(*ast.StructType); ok {
+			return typ
+		}
+
+		typ = exprString(spec.Type)
+	}
+
+	return typ
+}
+
+func description(field *ast.Field) string {
+	doc := field.Doc
+	if doc == nil {
+		doc = field.Comment
+	}
+
+	return strings.Join(strings.Fields(doc.Text()), " ")
+}
+
+func hasFlag(flags string, flag string) bool {
+	for f := range strings.SplitSeq(flags, ",") {
+		if strings.TrimSpace(f) == flag {
+			return true
+		}
+	}
+
+	return false
+}
+
+func exprString(expr ast.Expr) string {
+	var b strings.Builder
+	// Printing to a strings.Builder cannot fail.
+	_ = printer.Fprint(&b, token.NewFileSet(), expr)
+	return b.String()
+}
+
+func writeMarkdown(w io.Writer, vars []variable) error {
+	var b strings.Builder
+
+	b.WriteString("| Variable | Type | Default | Required | Description |\n")
+	b.WriteString("|----------|------|---------|----------|-------------|\n")
+
+	for _, v := range vars {
+		required := "no"
+		if v.Required {
+			required = "yes"
+		}
+
+		defaultValue := ""
+		if v.Default != "" {
+			defaultValue = "`" + escapeMarkdown(v.Default) + "`"
+		}
+
+		fmt.Fprintf(&b, "| `%s` | `%s` | %s | %s | %s |\n",
+			v.Name,
+			escapeMarkdown(v.Type),
+			defaultValue,
+			required,
+			escapeMarkdown(v.Description),
+		)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func escapeMarkdown(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+func writeJSON(w io.Writer, vars []variable) error {
+	if vars == nil {
+		vars = []variable{}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(vars)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidsbond/x/envvar"
+)
+
+var update = flag.Bool("update", false, "Update golden files")
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Name   string
+		Format string
+		Golden string
+		Write  func(*bytes.Buffer, []variable) error
+	}{
+		{
+			Name:   "outputs markdown",
+			Golden: "config.md.golden",
+			Write: func(b *bytes.Buffer, vars []variable) error {
+				return writeMarkdown(b, vars)
+			},
+		},
+		{
+			Name:   "outputs json",
+			Golden: "config.json.golden",
+			Write: func(b *bytes.Buffer, vars []variable) error {
+				return writeJSON(b, vars)
+			},
+		},
+	}
+
+	dir := filepath.Join("testdata", "config")
+	vars, err := describe(dir, "Config")
+	require.NoError(t, err)
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			var actual bytes.Buffer
+			require.NoError(t, tc.Write(&actual, vars))
+
+			golden := filepath.Join(dir, tc.Golden)
+			if *update {
+				require.NoError(t, os.WriteFile(golden, actual.Bytes(), 0o644))
+			}
+
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), actual.String())
+		})
+	}
+
+	t.Run("fails on missing type", func(t *testing.T) {
+		_, err := describe(dir, "Missing")
+		require.EqualError(t, err, "type Missing: not found")
+	})
+
+	t.Run("fails on non-struct type", func(t *testing.T) {
+		_, err := describe(dir, "Port")
+		require.EqualError(t, err, "type Port: not a struct")
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	vars, err := describe(filepath.Join("testdata", "config"), "Config")
+	require.NoError(t, err)
+
+	tt := []struct {
+		Name     string
+		Source   envvar.Map
+		Expected []string
+	}{
+		{
+			Name: "accepts valid environment",
+			Source: envvar.Map{
+				"DATABASE_URL": "postgres://localhost",
+				"PORT":         "9000",
+				"HOSTS":        "a|b",
+				"START_AT":     "2021-07-07",
+				"PREFIX":       "",
+				"CACHE_TTL":    "1m",
+				"REPLICA_HOST": "replica",
+			},
+		},
+		{
+			Name: "reports every problem",
+			Source: envvar.Map{
+				"PORT":        "70000",
+				"LOG_LEVEL":   "loud",
+				"ENVIRONMENT": "staging",
+				"START_AT":    "yesterday",
+				"CACHE_TTL":   "forever",
+				"DEBUG":       "maybe",
+				"ADMIN_PIN":   "secret",
+			},
+			Expected: []string{
+				"envvar: DATABASE_URL: required but not set",
+				`envvar: invalid value "70000" for PORT: strconv.ParseUint: parsing "70000": value out of range`,
+				`envvar: invalid value "loud" for LOG_LEVEL: slog: level string "loud": unknown name`,
+				`envvar: invalid value "staging" for ENVIRONMENT: must be one of [dev prod]`,
+				`envvar: invalid value "yesterday" for START_AT: parsing time "yesterday" as "2006-01-02": cannot parse "yesterday" as "2006"`,
+				`envvar: invalid value "[REDACTED]" for ADMIN_PIN: strconv.ParseInt: parsing "[REDACTED]": invalid syntax`,
+				`envvar: invalid value "forever" for CACHE_TTL: time: invalid duration "forever"`,
+				"envvar: REPLICA_HOST: required but not set",
+				`envvar: invalid value "maybe" for DEBUG: strconv.ParseBool: parsing "maybe": invalid syntax`,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual := validate(vars, tc.Source)
+			assert.EqualValues(t, tc.Expected, actual)
+		})
+	}
+}
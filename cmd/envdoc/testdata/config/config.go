@@ -0,0 +1,48 @@
+package config
+
+import (
+	"log/slog"
+	"net/url"
+	"time"
+)
+
+type (
+	Config struct {
+		// The URL of the database to connect to.
+		DatabaseURL *url.URL `env:"DATABASE_URL,required"`
+		// The port to serve HTTP traffic on.
+		Port Port `env:"PORT" default:"8080"`
+		// The log level, one of debug, info, warn or error.
+		LogLevel slog.Level `env:"LOG_LEVEL" default:"info"`
+		// The environment the application runs in.
+		Environment string      `env:"ENVIRONMENT" default:"dev" enum:"dev,prod"`
+		Hosts       []Host      `env:"HOSTS" sep:"|"` // Upstream hosts, separated by "|".
+		StartAt     time.Time   `env:"START_AT" layout:"2006-01-02"`
+		Prefix      string      `env:"PREFIX,allowempty"`
+		AdminPIN    int         `env:"ADMIN_PIN,sensitive"`
+		Cache       CacheConfig `prefix:"CACHE_"`
+		Replica     *DBConfig   `prefix:"REPLICA_"`
+		Fallback    *DBConfig
+		Shared
+
+		Ignored string
+	}
+
+	CacheConfig struct {
+		// How long entries remain in the cache.
+		TTL time.Duration `env:"TTL" default:"5m"`
+	}
+
+	DBConfig struct {
+		// The host of the database.
+		Host string `env:"HOST,required"`
+	}
+
+	Shared struct {
+		Debug bool `env:"DEBUG"`
+	}
+
+	Port uint16
+
+	Host string
+)
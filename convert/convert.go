@@ -1,6 +1,11 @@
 // Package convert provides functions for converting types from one to another.
 package convert
 
+import (
+	"errors"
+	"fmt"
+)
+
 // Slice maps a slice of one type into a slice of another using the provided conversion function.
 func Slice[Have, Want any](in []Have, fn func(Have) Want) []Want {
 	out := make([]Want, len(in))
@@ -21,3 +26,91 @@ func Map[Key comparable, Have any, Want any](in map[Key]Have, fn func(Key, Have)
 
 	return out
 }
+
+type (
+	// The Collision type determines how functions that produce maps handle multiple entries that convert to the same
+	// key.
+	Collision uint
+)
+
+const (
+	// KeepLast causes later entries to overwrite earlier ones that convert to the same key. When converting from a
+	// map, iteration order is not specified, so the entry that is kept is arbitrary.
+	KeepLast Collision = iota
+	// KeepFirst causes earlier entries to be kept over later ones that convert to the same key. When converting from
+	// a map, iteration order is not specified, so the entry that is kept is arbitrary.
+	KeepFirst
+	// Reject causes ErrCollision to be returned when multiple entries convert to the same key.
+	Reject
+)
+
+var (
+	// ErrCollision is the error given when multiple entries convert to the same key and the Reject policy is used.
+	ErrCollision = errors.New("collision")
+)
+
+// Rekey consumes a map, running each key and value through a conversion function, returning a new map with the same
+// values but transformed keys. The provided Collision policy determines how multiple entries that convert to the same
+// key are handled.
+func Rekey[Have, Want comparable, Value any](in map[Have]Value, fn func(Have, Value) Want, policy Collision) (map[Want]Value, error) {
+	out := make(map[Want]Value, len(in))
+	for k, v := range in {
+		if err := put(out, fn(k, v), v, policy); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// Invert consumes a map, returning a new map where each value is keyed to its original key. The provided Collision
+// policy determines how multiple keys sharing the same value are handled.
+func Invert[Key, Value comparable](in map[Key]Value, policy Collision) (map[Value]Key, error) {
+	out := make(map[Value]Key, len(in))
+	for k, v := range in {
+		if err := put(out, v, k, policy); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// KeyBy converts a slice into a map, where each element is keyed by the result of the provided function. The provided
+// Collision policy determines how multiple elements that produce the same key are handled.
+func KeyBy[Key comparable, Value any](in []Value, fn func(Value) Key, policy Collision) (map[Key]Value, error) {
+	out := make(map[Key]Value, len(in))
+	for _, v := range in {
+		if err := put(out, fn(v), v, policy); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// GroupBy converts a slice into a map of slices, where each element is grouped by the result of the provided function.
+// The order of elements within each group matches their order within the input slice.
+func GroupBy[Key comparable, Value any](in []Value, fn func(Value) Key) map[Key][]Value {
+	out := make(map[Key][]Value)
+	for _, v := range in {
+		key := fn(v)
+		out[key] = append(out[key], v)
+	}
+
+	return out
+}
+
+func put[Key comparable, Value any](m map[Key]Value, key Key, value Value, policy Collision) error {
+	if _, ok := m[key]; ok {
+		switch policy {
+		case KeepFirst:
+			return nil
+		case Reject:
+			return fmt.Errorf("%w: %v", ErrCollision, key)
+		}
+	}
+
+	m[key] = value
+	return nil
+}
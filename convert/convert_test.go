@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/davidsbond/x/convert"
 )
@@ -38,3 +39,122 @@ func TestMap(t *testing.T) {
 
 	assert.EqualValues(t, expected, actual)
 }
+
+func TestRekey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("converts keys", func(t *testing.T) {
+		input := map[int]string{1: "a", 2: "b"}
+		expected := map[string]string{"1": "a", "2": "b"}
+
+		actual, err := convert.Rekey(input, func(key int, value string) string {
+			return strconv.Itoa(key)
+		}, convert.Reject)
+
+		require.NoError(t, err)
+		assert.EqualValues(t, expected, actual)
+	})
+
+	t.Run("rejects collisions", func(t *testing.T) {
+		input := map[int]string{1: "a", 2: "b"}
+
+		actual, err := convert.Rekey(input, func(key int, value string) string {
+			return "same"
+		}, convert.Reject)
+
+		require.ErrorIs(t, err, convert.ErrCollision)
+		assert.Nil(t, actual)
+	})
+}
+
+func TestInvert(t *testing.T) {
+	t.Parallel()
+
+	t.Run("inverts map", func(t *testing.T) {
+		input := map[string]int{"a": 1, "b": 2}
+		expected := map[int]string{1: "a", 2: "b"}
+
+		actual, err := convert.Invert(input, convert.Reject)
+		require.NoError(t, err)
+		assert.EqualValues(t, expected, actual)
+	})
+
+	t.Run("keeps one value on collision", func(t *testing.T) {
+		input := map[string]int{"a": 1, "b": 1}
+
+		actual, err := convert.Invert(input, convert.KeepLast)
+		require.NoError(t, err)
+		require.Len(t, actual, 1)
+		assert.Contains(t, []string{"a", "b"}, actual[1])
+	})
+
+	t.Run("rejects collisions", func(t *testing.T) {
+		input := map[string]int{"a": 1, "b": 1}
+
+		_, err := convert.Invert(input, convert.Reject)
+		require.ErrorIs(t, err, convert.ErrCollision)
+	})
+}
+
+func TestKeyBy(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		ID   int
+		Name string
+	}
+
+	input := []user{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}, {ID: 1, Name: "c"}}
+	id := func(u user) int {
+		return u.ID
+	}
+
+	tt := []struct {
+		Name        string
+		Policy      convert.Collision
+		Expected    map[int]user
+		ExpectError bool
+	}{
+		{
+			Name:     "keeps last on collision",
+			Policy:   convert.KeepLast,
+			Expected: map[int]user{1: input[2], 2: input[1]},
+		},
+		{
+			Name:     "keeps first on collision",
+			Policy:   convert.KeepFirst,
+			Expected: map[int]user{1: input[0], 2: input[1]},
+		},
+		{
+			Name:        "rejects collisions",
+			Policy:      convert.Reject,
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := convert.KeyBy(input, id, tc.Policy)
+			if tc.ExpectError {
+				require.ErrorIs(t, err, convert.ErrCollision)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.EqualValues(t, tc.Expected, actual)
+		})
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	t.Parallel()
+
+	input := []int{1, 2, 3, 4, 5}
+	expected := map[bool][]int{true: {2, 4}, false: {1, 3, 5}}
+
+	actual := convert.GroupBy(input, func(value int) bool {
+		return value%2 == 0
+	})
+
+	assert.EqualValues(t, expected, actual)
+}
@@ -0,0 +1,129 @@
+package convert
+
+import (
+	"context"
+	"maps"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// SliceParallel maps a slice of one type into a slice of another using the provided conversion function, splitting
+// the input into chunks that are converted by at most the specified number of goroutines. If workers is less than one,
+// runtime.GOMAXPROCS is used. The order of the output matches the order of the input. The first error returned by the
+// conversion function cancels any remaining work and is returned. Cancellation of the provided context also stops any
+// remaining work, returning the context's error.
+func SliceParallel[Have, Want any](ctx context.Context, in []Have, workers int, fn func(context.Context, Have) (Want, error)) ([]Want, error) {
+	out := make([]Want, len(in))
+
+	err := parallel(ctx, len(in), workers, func(ctx context.Context, start, end int) error {
+		for i := start; i < end; i++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			value, err := fn(ctx, in[i])
+			if err != nil {
+				return err
+			}
+
+			out[i] = value
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// MapParallel consumes a map, running each key and value through a conversion function, returning a new map with the
+// same keys but transformed values. Entries are split into chunks that are converted by at most the specified number
+// of goroutines. If workers is less than one, runtime.GOMAXPROCS is used. The first error returned by the conversion
+// function cancels any remaining work and is returned. Cancellation of the provided context also stops any remaining
+// work, returning the context's error.
+func MapParallel[Key comparable, Have, Want any](ctx context.Context, in map[Key]Have, workers int, fn func(context.Context, Key, Have) (Want, error)) (map[Key]Want, error) {
+	keys := slices.Collect(maps.Keys(in))
+
+	// Values are converted into a slice indexed like the keys, so that workers never contend on the output map. The map
+	// is populated once every value has been converted.
+	values, err := SliceParallel(ctx, keys, workers, func(ctx context.Context, key Key) (Want, error) {
+		return fn(ctx, key, in[key])
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[Key]Want, len(keys))
+	for i, key := range keys {
+		out[key] = values[i]
+	}
+
+	return out, nil
+}
+
+// parallel splits the range [0, n) into chunks and invokes fn for each chunk using at most the specified number of
+// goroutines. The first error returned by fn cancels the context passed to all other invocations.
+func parallel(ctx context.Context, n int, workers int, fn func(ctx context.Context, start, end int) error) error {
+	if n == 0 {
+		return nil
+	}
+
+	workers = workerCount(workers)
+	size := chunkSize(n, workers)
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var (
+		group sync.WaitGroup
+		next  atomic.Int64
+		once  sync.Once
+		err   error
+	)
+
+	for range min(workers, (n+size-1)/size) {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+
+			for {
+				start := int(next.Add(int64(size))) - size
+				if start >= n {
+					return
+				}
+
+				if e := fn(ctx, start, min(start+size, n)); e != nil {
+					once.Do(func() {
+						err = e
+						cancel(e)
+					})
+					return
+				}
+			}
+		}()
+	}
+
+	group.Wait()
+	return err
+}
+
+func workerCount(workers int) int {
+	if workers < 1 {
+		return runtime.GOMAXPROCS(0)
+	}
+
+	return workers
+}
+
+// chunkSize determines how many elements each chunk should contain. Inputs are split into several chunks per worker
+// so that a slow chunk does not leave the remaining workers idle.
+func chunkSize(n int, workers int) int {
+	const chunksPerWorker = 4
+
+	chunks := workerCount(workers) * chunksPerWorker
+	return max(1, (n+chunks-1)/chunks)
+}
@@ -0,0 +1,135 @@
+package convert_test
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidsbond/x/convert"
+)
+
+func TestSliceParallel(t *testing.T) {
+	t.Parallel()
+
+	input := make([]int, 10000)
+	expected := make([]string, len(input))
+	for i := range input {
+		input[i] = i
+		expected[i] = strconv.Itoa(i)
+	}
+
+	t.Run("preserves order", func(t *testing.T) {
+		actual, err := convert.SliceParallel(t.Context(), input, 8, func(ctx context.Context, value int) (string, error) {
+			return strconv.Itoa(value), nil
+		})
+
+		require.NoError(t, err)
+		assert.EqualValues(t, expected, actual)
+	})
+
+	t.Run("bounds concurrency", func(t *testing.T) {
+		const workers = 3
+
+		var running, peak atomic.Int64
+		_, err := convert.SliceParallel(t.Context(), input, workers, func(ctx context.Context, value int) (string, error) {
+			current := running.Add(1)
+			defer running.Add(-1)
+
+			for {
+				p := peak.Load()
+				if current <= p || peak.CompareAndSwap(p, current) {
+					break
+				}
+			}
+
+			return strconv.Itoa(value), nil
+		})
+
+		require.NoError(t, err)
+		assert.LessOrEqual(t, peak.Load(), int64(workers))
+	})
+
+	t.Run("returns errors", func(t *testing.T) {
+		actual, err := convert.SliceParallel(t.Context(), input, 4, func(ctx context.Context, value int) (string, error) {
+			if value == 5000 {
+				return "", io.EOF
+			}
+
+			return strconv.Itoa(value), nil
+		})
+
+		require.ErrorIs(t, err, io.EOF)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("handles cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		actual, err := convert.SliceParallel(ctx, input, 4, func(ctx context.Context, value int) (string, error) {
+			return strconv.Itoa(value), nil
+		})
+
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("handles empty input", func(t *testing.T) {
+		actual, err := convert.SliceParallel(t.Context(), []int{}, 4, func(ctx context.Context, value int) (string, error) {
+			return strconv.Itoa(value), nil
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+}
+
+func TestMapParallel(t *testing.T) {
+	t.Parallel()
+
+	input := make(map[int]int, 10000)
+	expected := make(map[int]string, len(input))
+	for i := range 10000 {
+		input[i] = i
+		expected[i] = strconv.Itoa(i)
+	}
+
+	t.Run("converts values", func(t *testing.T) {
+		actual, err := convert.MapParallel(t.Context(), input, 8, func(ctx context.Context, key int, value int) (string, error) {
+			return strconv.Itoa(value), nil
+		})
+
+		require.NoError(t, err)
+		assert.EqualValues(t, expected, actual)
+	})
+
+	t.Run("returns errors", func(t *testing.T) {
+		actual, err := convert.MapParallel(t.Context(), input, 4, func(ctx context.Context, key int, value int) (string, error) {
+			if key == 5000 {
+				return "", io.EOF
+			}
+
+			return strconv.Itoa(value), nil
+		})
+
+		require.ErrorIs(t, err, io.EOF)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("handles cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		actual, err := convert.MapParallel(ctx, input, 4, func(ctx context.Context, key int, value int) (string, error) {
+			return strconv.Itoa(value), nil
+		})
+
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, actual)
+	})
+}
@@ -0,0 +1,178 @@
+package convert
+
+import (
+	"iter"
+)
+
+// Seq lazily maps an iter.Seq of one type into an iter.Seq of another using the provided conversion function. Values
+// are converted as they are consumed, no intermediate slice is allocated.
+func Seq[Have, Want any](in iter.Seq[Have], fn func(Have) Want) iter.Seq[Want] {
+	return func(yield func(Want) bool) {
+		for v := range in {
+			if !yield(fn(v)) {
+				return
+			}
+		}
+	}
+}
+
+// Seq2 lazily maps an iter.Seq2, running each key and value through a conversion function, returning a new iter.Seq2
+// with the same keys but transformed values.
+func Seq2[Key, Have, Want any](in iter.Seq2[Key, Have], fn func(Key, Have) Want) iter.Seq2[Key, Want] {
+	return func(yield func(Key, Want) bool) {
+		for k, v := range in {
+			if !yield(k, fn(k, v)) {
+				return
+			}
+		}
+	}
+}
+
+// FlatMap lazily maps each value of an iter.Seq into an iter.Seq of another type, yielding every value of each
+// resulting iter.Seq in turn.
+func FlatMap[Have, Want any](in iter.Seq[Have], fn func(Have) iter.Seq[Want]) iter.Seq[Want] {
+	return func(yield func(Want) bool) {
+		for v := range in {
+			for w := range fn(v) {
+				if !yield(w) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Zip combines two iter.Seq values into a single iter.Seq2 that yields pairs of values at the same position. Iteration
+// stops as soon as either iter.Seq is exhausted.
+func Zip[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		next, stop := iter.Pull(b)
+		defer stop()
+
+		for va := range a {
+			vb, ok := next()
+			if !ok || !yield(va, vb) {
+				return
+			}
+		}
+	}
+}
+
+// Enumerate returns an iter.Seq2 that yields the index of each value in the iter.Seq alongside the value itself.
+func Enumerate[T any](in iter.Seq[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		var i int
+		for v := range in {
+			if !yield(i, v) {
+				return
+			}
+
+			i++
+		}
+	}
+}
+
+// Chunk returns an iter.Seq that yields consecutive slices of up to size values from the iter.Seq. All but the last
+// slice will have the specified size. Each yielded slice is newly allocated and can be retained by the caller. Panics
+// if size is less than one.
+func Chunk[T any](in iter.Seq[T], size int) iter.Seq[[]T] {
+	if size < 1 {
+		panic("cannot chunk with size less than 1")
+	}
+
+	return func(yield func([]T) bool) {
+		chunk := make([]T, 0, size)
+		for v := range in {
+			chunk = append(chunk, v)
+			if len(chunk) < size {
+				continue
+			}
+
+			if !yield(chunk) {
+				return
+			}
+
+			chunk = make([]T, 0, size)
+		}
+
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// Take returns an iter.Seq that yields at most the first n values of the iter.Seq.
+func Take[T any](in iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+
+		var i int
+		for v := range in {
+			if !yield(v) {
+				return
+			}
+
+			i++
+			if i >= n {
+				return
+			}
+		}
+	}
+}
+
+// Take2 returns an iter.Seq2 that yields at most the first n pairs of the iter.Seq2.
+func Take2[K, V any](in iter.Seq2[K, V], n int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if n <= 0 {
+			return
+		}
+
+		var i int
+		for k, v := range in {
+			if !yield(k, v) {
+				return
+			}
+
+			i++
+			if i >= n {
+				return
+			}
+		}
+	}
+}
+
+// Skip returns an iter.Seq that yields all values of the iter.Seq after the first n.
+func Skip[T any](in iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var i int
+		for v := range in {
+			if i < n {
+				i++
+				continue
+			}
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Skip2 returns an iter.Seq2 that yields all pairs of the iter.Seq2 after the first n.
+func Skip2[K, V any](in iter.Seq2[K, V], n int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var i int
+		for k, v := range in {
+			if i < n {
+				i++
+				continue
+			}
+
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
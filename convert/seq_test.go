@@ -0,0 +1,208 @@
+package convert_test
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/davidsbond/x/convert"
+	"github.com/davidsbond/x/set"
+	"github.com/davidsbond/x/syncmap"
+	"github.com/davidsbond/x/syncslice"
+)
+
+func TestSeq(t *testing.T) {
+	t.Parallel()
+
+	t.Run("converts values", func(t *testing.T) {
+		input := slices.Values([]int{1, 2, 3})
+		expected := []string{"1", "2", "3"}
+
+		actual := slices.Collect(convert.Seq(input, strconv.Itoa))
+		assert.EqualValues(t, expected, actual)
+	})
+
+	t.Run("converts set values", func(t *testing.T) {
+		s := set.New[int]()
+		s.Put(1)
+		s.Put(2)
+
+		actual := slices.Collect(convert.Seq(s.Range(), strconv.Itoa))
+		assert.ElementsMatch(t, []string{"1", "2"}, actual)
+	})
+
+	t.Run("stops early", func(t *testing.T) {
+		var calls int
+		seq := convert.Seq(slices.Values([]int{1, 2, 3}), func(v int) string {
+			calls++
+			return strconv.Itoa(v)
+		})
+
+		for range seq {
+			break
+		}
+
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestSeq2(t *testing.T) {
+	t.Parallel()
+
+	t.Run("converts syncmap values", func(t *testing.T) {
+		m := syncmap.New[string, int]()
+		m.Put("a", 1)
+		m.Put("b", 2)
+
+		expected := map[string]string{"a": "1", "b": "2"}
+		actual := maps.Collect(convert.Seq2(m.Range(), func(key string, value int) string {
+			return strconv.Itoa(value)
+		}))
+
+		assert.EqualValues(t, expected, actual)
+	})
+
+	t.Run("converts syncslice values", func(t *testing.T) {
+		s := syncslice.New[int]()
+		s.Append(1, 2, 3)
+
+		expected := map[uint]string{0: "1", 1: "2", 2: "3"}
+		actual := maps.Collect(convert.Seq2(s.Range(), func(idx uint, value int) string {
+			return strconv.Itoa(value)
+		}))
+
+		assert.EqualValues(t, expected, actual)
+	})
+}
+
+func TestFlatMap(t *testing.T) {
+	t.Parallel()
+
+	input := slices.Values([]int{1, 2, 3})
+	expected := []int{1, 2, 2, 3, 3, 3}
+
+	actual := slices.Collect(convert.FlatMap(input, func(v int) iter.Seq[int] {
+		return slices.Values(slices.Repeat([]int{v}, v))
+	}))
+
+	assert.EqualValues(t, expected, actual)
+}
+
+func TestZip(t *testing.T) {
+	t.Parallel()
+
+	a := slices.Values([]string{"a", "b", "c"})
+	b := slices.Values([]int{1, 2})
+	expected := map[string]int{"a": 1, "b": 2}
+
+	actual := maps.Collect(convert.Zip(a, b))
+	assert.EqualValues(t, expected, actual)
+}
+
+func TestEnumerate(t *testing.T) {
+	t.Parallel()
+
+	input := slices.Values([]string{"a", "b", "c"})
+	expected := map[int]string{0: "a", 1: "b", 2: "c"}
+
+	actual := maps.Collect(convert.Enumerate(input))
+	assert.EqualValues(t, expected, actual)
+}
+
+func TestChunk(t *testing.T) {
+	t.Parallel()
+
+	t.Run("chunks values", func(t *testing.T) {
+		input := slices.Values([]int{1, 2, 3, 4, 5})
+		expected := [][]int{{1, 2}, {3, 4}, {5}}
+
+		actual := slices.Collect(convert.Chunk(input, 2))
+		assert.EqualValues(t, expected, actual)
+	})
+
+	t.Run("panics on invalid size", func(t *testing.T) {
+		assert.Panics(t, func() {
+			convert.Chunk(slices.Values([]int{1}), 0)
+		})
+	})
+}
+
+func TestTake(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Name     string
+		Input    []int
+		N        int
+		Expected []int
+	}{
+		{
+			Name:     "takes first values",
+			Input:    []int{1, 2, 3},
+			N:        2,
+			Expected: []int{1, 2},
+		},
+		{
+			Name:     "takes all values when n exceeds length",
+			Input:    []int{1, 2, 3},
+			N:        5,
+			Expected: []int{1, 2, 3},
+		},
+		{
+			Name:  "takes nothing when n is zero",
+			Input: []int{1, 2, 3},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual := slices.Collect(convert.Take(slices.Values(tc.Input), tc.N))
+			assert.EqualValues(t, tc.Expected, actual)
+
+			actual2 := slices.Collect(maps.Values(maps.Collect(convert.Take2(slices.All(tc.Input), tc.N))))
+			assert.ElementsMatch(t, tc.Expected, actual2)
+		})
+	}
+}
+
+func TestSkip(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Name     string
+		Input    []int
+		N        int
+		Expected []int
+	}{
+		{
+			Name:     "skips first values",
+			Input:    []int{1, 2, 3},
+			N:        2,
+			Expected: []int{3},
+		},
+		{
+			Name:  "skips all values when n exceeds length",
+			Input: []int{1, 2, 3},
+			N:     5,
+		},
+		{
+			Name:     "skips nothing when n is zero",
+			Input:    []int{1, 2, 3},
+			Expected: []int{1, 2, 3},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual := slices.Collect(convert.Skip(slices.Values(tc.Input), tc.N))
+			assert.EqualValues(t, tc.Expected, actual)
+
+			actual2 := slices.Collect(maps.Values(maps.Collect(convert.Skip2(slices.All(tc.Input), tc.N))))
+			assert.ElementsMatch(t, tc.Expected, actual2)
+		})
+	}
+}
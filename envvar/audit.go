@@ -0,0 +1,194 @@
+package envvar
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+type (
+	// The Audit type records the lookup of environment variables, including where each value came from, whether the
+	// default value was used and any error parsing the value. Values of environment variables marked as sensitive are
+	// redacted before being recorded. By default, all lookups are recorded to the Audit returned by DefaultAudit. The
+	// Audit can be logged via log/slog, as it implements slog.LogValuer, or served over HTTP, as it implements
+	// http.Handler.
+	Audit struct {
+		mux     sync.RWMutex
+		records map[string]Record
+	}
+
+	// The Record type describes the most recent lookup of an environment variable.
+	Record struct {
+		// The name of the environment variable.
+		Key string
+		// The effective value, either the value of the environment variable or the default value. This is Redacted
+		// for sensitive environment variables.
+		Value string
+		// The source the value was read from. Empty when the environment variable was not set.
+		Source string
+		// Indicates the default value was used, either because the environment variable was not set or its value
+		// could not be parsed.
+		Defaulted bool
+		// Indicates the environment variable was marked as sensitive.
+		Sensitive bool
+		// Any error that occurred reading or parsing the value.
+		Err error
+	}
+)
+
+const (
+	// Redacted replaces the values of sensitive environment variables within records and errors.
+	Redacted = "[REDACTED]"
+)
+
+var defaultAudit = NewAudit()
+
+// NewAudit returns a new, empty Audit.
+func NewAudit() *Audit {
+	return &Audit{
+		records: make(map[string]Record),
+	}
+}
+
+// DefaultAudit returns the Audit that lookups are recorded to when the AuditTo option is not provided.
+func DefaultAudit() *Audit {
+	return defaultAudit
+}
+
+// AuditTo returns an Option that records lookups to the given Audit rather than the one returned by DefaultAudit. If
+// the given Audit is nil, lookups are not recorded.
+func AuditTo(audit *Audit) Option {
+	return func(o *options) {
+		o.audit = audit
+	}
+}
+
+// Sensitive returns an Option that marks the environment variable as sensitive. Its value is redacted within the
+// Audit and within any *Error returned when parsing it.
+func Sensitive() Option {
+	return func(o *options) {
+		o.sensitive = true
+	}
+}
+
+// Records returns the most recent Record for each environment variable that has been looked up, ordered by key.
+func (a *Audit) Records() []Record {
+	a.mux.RLock()
+	defer a.mux.RUnlock()
+
+	return slices.SortedFunc(maps.Values(a.records), func(a, b Record) int {
+		return cmp.Compare(a.Key, b.Key)
+	})
+}
+
+// Reset removes all records from the Audit.
+func (a *Audit) Reset() {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	a.records = make(map[string]Record)
+}
+
+// LogValue returns a group containing an attribute for each environment variable that has been looked up, allowing
+// the effective configuration to be logged:
+//
+//	slog.Info("loaded configuration", "env", envvar.DefaultAudit())
+func (a *Audit) LogValue() slog.Value {
+	records := a.Records()
+
+	attrs := make([]slog.Attr, len(records))
+	for i, record := range records {
+		attrs[i] = slog.Any(record.Key, record)
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// ServeHTTP writes all records as a JSON array. It is intended to be mounted on a debug endpoint.
+func (a *Audit) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(a.Records()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// LogValue returns a group describing the Record.
+func (r Record) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("value", r.Value),
+		slog.Bool("defaulted", r.Defaulted),
+	}
+
+	if r.Source != "" {
+		attrs = append(attrs, slog.String("source", r.Source))
+	}
+
+	if r.Err != nil {
+		attrs = append(attrs, slog.String("error", r.Err.Error()))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// MarshalJSON encodes the Record as a JSON object.
+func (r Record) MarshalJSON() ([]byte, error) {
+	var errMessage string
+	if r.Err != nil {
+		errMessage = r.Err.Error()
+	}
+
+	return json.Marshal(struct {
+		Key       string `json:"key"`
+		Value     string `json:"value"`
+		Source    string `json:"source,omitempty"`
+		Defaulted bool   `json:"defaulted"`
+		Sensitive bool   `json:"sensitive"`
+		Error     string `json:"error,omitempty"`
+	}{
+		Key:       r.Key,
+		Value:     r.Value,
+		Source:    r.Source,
+		Defaulted: r.Defaulted,
+		Sensitive: r.Sensitive,
+		Error:     errMessage,
+	})
+}
+
+func (a *Audit) record(r Record) {
+	if a == nil {
+		return
+	}
+
+	if r.Sensitive {
+		r.Value = Redacted
+	}
+
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	a.records[r.Key] = r
+}
+
+// sourceName returns a description of the source that provides the given key. For layered sources, this is the
+// first layer in which the key is set.
+func sourceName(source Source, key string) string {
+	if l, ok := source.(layered); ok {
+		for _, s := range l {
+			if _, ok = s.Lookup(key); ok {
+				return sourceName(s, key)
+			}
+		}
+	}
+
+	if s, ok := source.(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	return fmt.Sprintf("%T", source)
+}
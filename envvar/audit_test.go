@@ -0,0 +1,129 @@
+package envvar_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidsbond/x/envvar"
+)
+
+func TestAudit(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(path, []byte("hunter2\n"), 0o600))
+
+	source := envvar.Layered(
+		envvar.Map{"PORT": "8080", "INVALID": "abc", "TOKEN": "secret", "PASSWORD_FILE": path},
+		envvar.OS,
+	)
+
+	t.Run("records lookups", func(t *testing.T) {
+		audit := envvar.NewAudit()
+		opts := []envvar.Option{envvar.From(source), envvar.AuditTo(audit)}
+
+		envvar.Int("PORT", 0, opts...)
+		envvar.String("MISSING", "default", opts...)
+		envvar.Int("INVALID", 10, opts...)
+		envvar.String("PASSWORD", "", opts...)
+
+		records := audit.Records()
+		require.Len(t, records, 4)
+
+		assert.Equal(t, envvar.Record{Key: "INVALID", Value: "10", Source: "map", Defaulted: true}, withoutErr(records[0]))
+		assert.Error(t, records[0].Err)
+		assert.Equal(t, envvar.Record{Key: "MISSING", Value: "default", Defaulted: true}, records[1])
+		assert.Equal(t, envvar.Record{Key: "PASSWORD", Value: "hunter2", Source: "file:" + path}, records[2])
+		assert.Equal(t, envvar.Record{Key: "PORT", Value: "8080", Source: "map"}, records[3])
+	})
+
+	t.Run("redacts sensitive values", func(t *testing.T) {
+		audit := envvar.NewAudit()
+
+		_, err := envvar.StrictInt("TOKEN", 0, envvar.From(source), envvar.AuditTo(audit), envvar.Sensitive())
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), "secret")
+
+		var target *envvar.Error
+		require.ErrorAs(t, err, &target)
+		assert.Equal(t, envvar.Redacted, target.Value)
+
+		records := audit.Records()
+		require.Len(t, records, 1)
+		assert.Equal(t, envvar.Redacted, records[0].Value)
+		assert.True(t, records[0].Sensitive)
+	})
+
+	t.Run("redacts sensitive fields", func(t *testing.T) {
+		audit := envvar.NewAudit()
+
+		var cfg struct {
+			Port  int    `env:"PORT"`
+			Token string `env:"TOKEN,sensitive"`
+		}
+
+		require.NoError(t, envvar.Load(&cfg, envvar.From(source), envvar.AuditTo(audit)))
+		assert.Equal(t, "secret", cfg.Token)
+
+		records := audit.Records()
+		require.Len(t, records, 2)
+		assert.Equal(t, envvar.Record{Key: "PORT", Value: "8080", Source: "map"}, records[0])
+		assert.Equal(t, envvar.Record{Key: "TOKEN", Value: envvar.Redacted, Source: "map", Sensitive: true}, records[1])
+	})
+
+	t.Run("does not record when disabled", func(t *testing.T) {
+		assert.EqualValues(t, 8080, envvar.Int("PORT", 0, envvar.From(source), envvar.AuditTo(nil)))
+	})
+
+	t.Run("resets", func(t *testing.T) {
+		audit := envvar.NewAudit()
+		envvar.Int("PORT", 0, envvar.From(source), envvar.AuditTo(audit))
+		require.Len(t, audit.Records(), 1)
+
+		audit.Reset()
+		assert.Empty(t, audit.Records())
+	})
+
+	t.Run("logs records", func(t *testing.T) {
+		audit := envvar.NewAudit()
+		envvar.Int("PORT", 0, envvar.From(source), envvar.AuditTo(audit))
+		envvar.String("TOKEN", "", envvar.From(source), envvar.AuditTo(audit), envvar.Sensitive())
+
+		buf := bytes.NewBuffer(nil)
+		slog.New(slog.NewJSONHandler(buf, nil)).Info("loaded configuration", "env", audit)
+
+		var actual struct {
+			Env map[string]map[string]any `json:"env"`
+		}
+
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &actual))
+		assert.Equal(t, map[string]any{"value": "8080", "defaulted": false, "source": "map"}, actual.Env["PORT"])
+		assert.Equal(t, map[string]any{"value": envvar.Redacted, "defaulted": false, "source": "map"}, actual.Env["TOKEN"])
+	})
+
+	t.Run("serves records", func(t *testing.T) {
+		audit := envvar.NewAudit()
+		envvar.Int("PORT", 0, envvar.From(source), envvar.AuditTo(audit))
+
+		w := httptest.NewRecorder()
+		audit.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		assert.JSONEq(t, `[{"key":"PORT","value":"8080","source":"map","defaulted":false,"sensitive":false}]`, w.Body.String())
+	})
+}
+
+func withoutErr(r envvar.Record) envvar.Record {
+	r.Err = nil
+	return r
+}
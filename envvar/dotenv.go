@@ -0,0 +1,316 @@
+package envvar
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+type (
+	dotenvParser struct {
+		src  string
+		pos  int
+		line int
+		out  Map
+	}
+)
+
+// ReadDotEnvFile parses the .env file at the given path, returning its environment variables as a Map. See ParseDotEnv
+// for details on the supported syntax.
+func ReadDotEnvFile(path string) (Map, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseDotEnv(f)
+}
+
+// ParseDotEnv parses environment variables in the .env file format from the given io.Reader, returning them as a Map.
+// Each line contains a single KEY=VALUE pair, optionally prefixed with "export". Blank lines and lines starting with
+// "#" are ignored. Values may be:
+//
+//   - Unquoted, where surrounding whitespace is trimmed and a "#" preceded by whitespace starts a comment.
+//   - Single quoted, where the value is taken literally and may span multiple lines.
+//   - Double quoted, where the escape sequences \n, \r, \t, \", \\ and \$ are supported and the value may span
+//     multiple lines.
+//
+// Unquoted and double-quoted values expand references to other variables in the form $KEY or ${KEY}. References are
+// resolved using variables defined earlier in the input, falling back to the process environment. References to
+// variables that are not set expand to an empty string.
+func ParseDotEnv(r io.Reader) (Map, error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &dotenvParser{
+		src:  strings.ReplaceAll(string(src), "\r\n", "\n"),
+		line: 1,
+		out:  make(Map),
+	}
+
+	if err = p.parse(); err != nil {
+		return nil, err
+	}
+
+	return p.out, nil
+}
+
+func (p *dotenvParser) parse() error {
+	for {
+		p.skipSpace()
+		if p.eof() {
+			return nil
+		}
+
+		if p.peek() == '#' {
+			p.skipLine()
+			continue
+		}
+
+		key, err := p.parseKey()
+		if err != nil {
+			return err
+		}
+
+		value, err := p.parseValue()
+		if err != nil {
+			return err
+		}
+
+		p.out[key] = value
+	}
+}
+
+func (p *dotenvParser) parseKey() (string, error) {
+	start := p.pos
+	for !p.eof() && isKeyChar(p.peek()) {
+		p.pos++
+	}
+
+	key := p.src[start:p.pos]
+	if key == "export" && !p.eof() && isBlank(p.peek()) {
+		p.skipBlank()
+		return p.parseKey()
+	}
+
+	if key == "" || isDigit(key[0]) {
+		return "", p.errorf("invalid key")
+	}
+
+	p.skipBlank()
+	if p.eof() || p.peek() != '=' {
+		return "", p.errorf("expected '=' after key %s", key)
+	}
+
+	p.pos++
+	p.skipBlank()
+	return key, nil
+}
+
+func (p *dotenvParser) parseValue() (string, error) {
+	if p.eof() {
+		return "", nil
+	}
+
+	var (
+		value string
+		err   error
+	)
+
+	switch p.peek() {
+	case '\'':
+		value, err = p.parseSingleQuoted()
+	case '"':
+		value, err = p.parseDoubleQuoted()
+	default:
+		return p.parseUnquoted(), nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	// Only whitespace or a comment may follow a quoted value.
+	p.skipBlank()
+	if !p.eof() && p.peek() != '\n' && p.peek() != '#' {
+		return "", p.errorf("unexpected character %q after quoted value", p.peek())
+	}
+
+	p.skipLine()
+	return value, nil
+}
+
+func (p *dotenvParser) parseSingleQuoted() (string, error) {
+	line := p.line
+	p.pos++
+
+	end := strings.IndexByte(p.src[p.pos:], '\'')
+	if end < 0 {
+		p.line = line
+		return "", p.errorf("unterminated single-quoted value")
+	}
+
+	value := p.src[p.pos : p.pos+end]
+	p.line += strings.Count(value, "\n")
+	p.pos += end + 1
+	return value, nil
+}
+
+func (p *dotenvParser) parseDoubleQuoted() (string, error) {
+	line := p.line
+	p.pos++
+
+	var b strings.Builder
+	for !p.eof() {
+		c := p.peek()
+		switch {
+		case c == '"':
+			p.pos++
+			return b.String(), nil
+		case c == '\\' && p.pos+1 < len(p.src):
+			p.pos++
+			switch e := p.peek(); e {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case '"', '\\', '$':
+				b.WriteByte(e)
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(e)
+			}
+			p.pos++
+		case c == '$':
+			b.WriteString(p.expand())
+		default:
+			if c == '\n' {
+				p.line++
+			}
+
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+
+	p.line = line
+	return "", p.errorf("unterminated double-quoted value")
+}
+
+func (p *dotenvParser) parseUnquoted() string {
+	var b strings.Builder
+	for !p.eof() {
+		c := p.peek()
+		if c == '\n' {
+			break
+		}
+
+		// A "#" only starts a comment when preceded by whitespace, so that values such as URL fragments are kept.
+		if c == '#' && p.pos > 0 && isBlank(p.src[p.pos-1]) {
+			p.skipLine()
+			break
+		}
+
+		if c == '$' {
+			b.WriteString(p.expand())
+			continue
+		}
+
+		b.WriteByte(c)
+		p.pos++
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+// expand consumes a variable reference in the form $KEY or ${KEY} and returns its value. A "$" that does not begin a
+// valid reference is returned as-is.
+func (p *dotenvParser) expand() string {
+	p.pos++
+
+	braced := !p.eof() && p.peek() == '{'
+	if braced {
+		p.pos++
+	}
+
+	start := p.pos
+	for !p.eof() && isKeyChar(p.peek()) {
+		p.pos++
+	}
+
+	key := p.src[start:p.pos]
+	if braced {
+		if p.eof() || p.peek() != '}' {
+			p.pos = start
+			return "${"
+		}
+
+		p.pos++
+	}
+
+	if key == "" {
+		return "$"
+	}
+
+	if value, ok := p.out[key]; ok {
+		return value
+	}
+
+	return os.Getenv(key)
+}
+
+func (p *dotenvParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("envvar: line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+func (p *dotenvParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *dotenvParser) peek() byte {
+	return p.src[p.pos]
+}
+
+func (p *dotenvParser) skipSpace() {
+	for !p.eof() {
+		switch p.peek() {
+		case '\n':
+			p.line++
+		case ' ', '\t', '\r':
+		default:
+			return
+		}
+
+		p.pos++
+	}
+}
+
+func (p *dotenvParser) skipBlank() {
+	for !p.eof() && isBlank(p.peek()) {
+		p.pos++
+	}
+}
+
+func (p *dotenvParser) skipLine() {
+	for !p.eof() && p.peek() != '\n' {
+		p.pos++
+	}
+}
+
+func isBlank(c byte) bool {
+	return c == ' ' || c == '\t'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isKeyChar(c byte) bool {
+	return c == '_' || c == '.' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
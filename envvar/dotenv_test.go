@@ -0,0 +1,104 @@
+package envvar_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidsbond/x/envvar"
+)
+
+func TestParseDotEnv(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Name          string
+		Input         string
+		Expected      envvar.Map
+		ExpectedError string
+	}{
+		{
+			Name:     "parses unquoted values",
+			Input:    "A=1\nB = two words  \n\n\nC=",
+			Expected: envvar.Map{"A": "1", "B": "two words", "C": ""},
+		},
+		{
+			Name:     "ignores comments",
+			Input:    "# comment\nA=1 # trailing comment\nB=http://host/#fragment\n  # indented comment",
+			Expected: envvar.Map{"A": "1", "B": "http://host/#fragment"},
+		},
+		{
+			Name:     "supports export",
+			Input:    "export A=1\nexport\tB=2\nexport=3",
+			Expected: envvar.Map{"A": "1", "B": "2", "export": "3"},
+		},
+		{
+			Name:     "parses single quoted values",
+			Input:    "A='$B \\n # not a comment'\nB='multi\nline' # comment",
+			Expected: envvar.Map{"A": "$B \\n # not a comment", "B": "multi\nline"},
+		},
+		{
+			Name:     "parses double quoted values",
+			Input:    `A="tab\there\nnewline \"quoted\" \\ \$HOME \q"` + "\nB=\"multi\nline\"",
+			Expected: envvar.Map{"A": "tab\there\nnewline \"quoted\" \\ $HOME \\q", "B": "multi\nline"},
+		},
+		{
+			Name:     "expands variables",
+			Input:    "HOST=localhost\nPORT=5432\nURL=postgres://${HOST}:$PORT/db\nQUOTED=\"$HOST\"\nLITERAL='$HOST'\nPRICE=5$",
+			Expected: envvar.Map{"HOST": "localhost", "PORT": "5432", "URL": "postgres://localhost:5432/db", "QUOTED": "localhost", "LITERAL": "$HOST", "PRICE": "5$"},
+		},
+		{
+			Name:     "handles windows line endings",
+			Input:    "A=1\r\nB=\"2\"\r\n",
+			Expected: envvar.Map{"A": "1", "B": "2"},
+		},
+		{
+			Name:          "errors on missing equals",
+			Input:         "A=1\nB",
+			ExpectedError: "envvar: line 2: expected '=' after key B",
+		},
+		{
+			Name:          "errors on invalid key",
+			Input:         "1A=1",
+			ExpectedError: "envvar: line 1: invalid key",
+		},
+		{
+			Name:          "errors on unterminated quotes",
+			Input:         "A=1\nB=\"abc\n\nC=2",
+			ExpectedError: "envvar: line 2: unterminated double-quoted value",
+		},
+		{
+			Name:          "errors on trailing characters",
+			Input:         "A='abc' def",
+			ExpectedError: "envvar: line 1: unexpected character 'd' after quoted value",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := envvar.ParseDotEnv(strings.NewReader(tc.Input))
+			if tc.ExpectedError != "" {
+				require.EqualError(t, err, tc.ExpectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.EqualValues(t, tc.Expected, actual)
+		})
+	}
+}
+
+func TestReadDotEnvFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(path, []byte("A=1\nB=2\n"), 0o600))
+
+	actual, err := envvar.ReadDotEnvFile(path)
+	require.NoError(t, err)
+	assert.EqualValues(t, envvar.Map{"A": "1", "B": "2"}, actual)
+}
@@ -1,90 +1,273 @@
 // Package envvar provides functions for working with environment variables. Primarily with parsing their string
-// values into different types and managing default values.
+// values into different types and managing default values. By default, values are read from the process environment,
+// other sources such as maps and .env files can be used via the From option.
+//
+// Environment variables that are set to an empty string are treated as not set and produce the default value. Use
+// the AllowEmpty option or SetAllowEmpty to treat them as set instead, or Lookup to distinguish between the two.
+//
+// When an environment variable is not set, its value is read from the file named by the same environment variable
+// suffixed with "_FILE", if it is set. For example, DB_PASSWORD is read from the file at the path given by
+// DB_PASSWORD_FILE. This supports the convention used for Docker and Kubernetes secrets. A single trailing newline is
+// removed from the file's contents.
 package envvar
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 )
 
+type (
+	// The Error type describes an environment variable whose value could not be parsed, or that is required but not
+	// set. It is returned by the Strict variants of each function and by Load.
+	Error struct {
+		// The name of the environment variable.
+		Key string
+		// The raw value of the environment variable. This is Redacted for environment variables marked as sensitive.
+		Value string
+		// The error returned when parsing the value.
+		Err error
+	}
+)
+
+var (
+	// ErrRequired is the error given when loading configuration where a required environment variable is not set.
+	ErrRequired = errors.New("required but not set")
+)
+
+// Error returns a description of the error, including the key and raw value of the environment variable.
+func (e *Error) Error() string {
+	if errors.Is(e.Err, ErrRequired) {
+		return fmt.Sprintf("envvar: %s: %v", e.Key, e.Err)
+	}
+
+	return fmt.Sprintf("envvar: invalid value %q for %s: %v", e.Value, e.Key, e.Err)
+}
+
+// Unwrap returns the error returned when parsing the value.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Lookup returns the value of the specified environment variable and whether it is set, like os.LookupEnv. Unlike
+// the other functions in this package, environment variables set to an empty string are always reported as set and
+// values are not read from files named by "_FILE" environment variables.
+func Lookup(key string, opts ...Option) (string, bool) {
+	o := newOptions(opts)
+
+	value, ok := o.source.Lookup(key)
+	if ok {
+		o.record(Record{Key: key, Value: value, Source: sourceName(o.source, key)})
+	} else {
+		o.record(Record{Key: key, Defaulted: true})
+	}
+
+	return value, ok
+}
+
 // String returns the value of the specified environment variable as a string. Returns the specified default value when
 // the environment variable is not set.
-func String(key string, def string) string {
+func String(key string, def string, opts ...Option) string {
 	return parse(key, def, func(s string) (string, error) {
 		return s, nil
-	})
+	}, opts)
 }
 
 // StringSlice returns the value of the specified environment variable as a slice of strings. Returns the specified
-// default value when the environment variable is not set.
-func StringSlice(key string, sep string, def []string) []string {
+// default value when the environment variable is not set. When empty values are allowed, an environment variable set
+// to an empty string produces an empty slice.
+func StringSlice(key string, sep string, def []string, opts ...Option) []string {
 	return parse(key, def, func(s string) ([]string, error) {
+		if s == "" {
+			return []string{}, nil
+		}
+
 		return strings.Split(s, sep), nil
-	})
+	}, opts)
 }
 
 // Int returns the value of the specified environment variable as an integer. Returns the specified default value when
 // the environment variable is not set or cannot be parsed.
-func Int(key string, def int) int {
-	return parse(key, def, strconv.Atoi)
+func Int(key string, def int, opts ...Option) int {
+	return parse(key, def, strconv.Atoi, opts)
 }
 
 // Int64 returns the value of the specified environment variable as a 64-bit integer. Returns the specified default
 // value when the environment variable is not set or cannot be parsed.
-func Int64(key string, def int64) int64 {
-	return parse(key, def, func(s string) (int64, error) {
-		return strconv.ParseInt(s, 10, 64)
-	})
+func Int64(key string, def int64, opts ...Option) int64 {
+	return parse(key, def, parseInt64, opts)
 }
 
 // Bool returns the value of the specified environment variable as a boolean. Accepts "true" and "false". Returns the
 // specified default value when the environment variable is not set or cannot be parsed.
-func Bool(key string, def bool) bool {
-	return parse(key, def, strconv.ParseBool)
+func Bool(key string, def bool, opts ...Option) bool {
+	return parse(key, def, strconv.ParseBool, opts)
 }
 
 // Float64 returns the value of the specified environment variable as a 64-bit floating point number. Returns the
 // specified default value when the environment variable is not set or cannot be parsed.
-func Float64(key string, def float64) float64 {
-	return parse(key, def, func(s string) (float64, error) {
-		return strconv.ParseFloat(s, 64)
-	})
+func Float64(key string, def float64, opts ...Option) float64 {
+	return parse(key, def, parseFloat64, opts)
 }
 
 // Uint64 returns the value of the specified environment variable as an unsigned 64-bit integer. Returns the specified
 // default value when the environment variable is not set or cannot be parsed.
-func Uint64(key string, def uint64) uint64 {
-	return parse(key, def, func(s string) (uint64, error) {
-		return strconv.ParseUint(s, 10, 64)
-	})
+func Uint64(key string, def uint64, opts ...Option) uint64 {
+	return parse(key, def, parseUint64, opts)
 }
 
 // Time returns the value of the specified environment variable as a time.Time using the specified format. Returns the
 // specified default value when the environment variable is not set or cannot be parsed.
-func Time(key string, format string, def time.Time) time.Time {
-	return parse(key, def, func(s string) (time.Time, error) {
-		return time.Parse(format, s)
-	})
+func Time(key string, format string, def time.Time, opts ...Option) time.Time {
+	return parse(key, def, parseTime(format), opts)
 }
 
 // Duration returns the value of the specified environment variable as a time.Duration. Returns the specified default
 // value when the environment variable is not set or cannot be parsed.
-func Duration(key string, def time.Duration) time.Duration {
-	return parse(key, def, time.ParseDuration)
+func Duration(key string, def time.Duration, opts ...Option) time.Duration {
+	return parse(key, def, time.ParseDuration, opts)
+}
+
+// Must returns the value if err is nil and panics otherwise. It is intended to wrap calls to the Strict variants of
+// each function so that misconfiguration fails fast during application startup:
+//
+//	port := envvar.Must(envvar.StrictInt("PORT", 8080))
+func Must[T any](value T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+
+	return value
+}
+
+// StrictInt returns the value of the specified environment variable as an integer. Returns the specified default
+// value when the environment variable is not set. Returns the default value and an *Error if the value cannot be
+// parsed.
+func StrictInt(key string, def int, opts ...Option) (int, error) {
+	return strictParse(key, def, strconv.Atoi, opts)
+}
+
+// StrictInt64 returns the value of the specified environment variable as a 64-bit integer. Returns the specified
+// default value when the environment variable is not set. Returns the default value and an *Error if the value cannot
+// be parsed.
+func StrictInt64(key string, def int64, opts ...Option) (int64, error) {
+	return strictParse(key, def, parseInt64, opts)
+}
+
+// StrictBool returns the value of the specified environment variable as a boolean. Accepts "true" and "false". Returns
+// the specified default value when the environment variable is not set. Returns the default value and an *Error if the
+// value cannot be parsed.
+func StrictBool(key string, def bool, opts ...Option) (bool, error) {
+	return strictParse(key, def, strconv.ParseBool, opts)
+}
+
+// StrictFloat64 returns the value of the specified environment variable as a 64-bit floating point number. Returns the
+// specified default value when the environment variable is not set. Returns the default value and an *Error if the
+// value cannot be parsed.
+func StrictFloat64(key string, def float64, opts ...Option) (float64, error) {
+	return strictParse(key, def, parseFloat64, opts)
+}
+
+// StrictUint64 returns the value of the specified environment variable as an unsigned 64-bit integer. Returns the
+// specified default value when the environment variable is not set. Returns the default value and an *Error if the
+// value cannot be parsed.
+func StrictUint64(key string, def uint64, opts ...Option) (uint64, error) {
+	return strictParse(key, def, parseUint64, opts)
 }
 
-func parse[T any](key string, def T, parser func(string) (T, error)) T {
-	str := os.Getenv(key)
-	if str == "" {
-		return def
+// StrictTime returns the value of the specified environment variable as a time.Time using the specified format.
+// Returns the specified default value when the environment variable is not set. Returns the default value and an
+// *Error if the value cannot be parsed.
+func StrictTime(key string, format string, def time.Time, opts ...Option) (time.Time, error) {
+	return strictParse(key, def, parseTime(format), opts)
+}
+
+// StrictDuration returns the value of the specified environment variable as a time.Duration. Returns the specified
+// default value when the environment variable is not set. Returns the default value and an *Error if the value cannot
+// be parsed.
+func StrictDuration(key string, def time.Duration, opts ...Option) (time.Duration, error) {
+	return strictParse(key, def, time.ParseDuration, opts)
+}
+
+func parse[T any](key string, def T, parser func(string) (T, error), opts []Option) T {
+	value, _ := strictParse(key, def, parser, opts)
+	return value
+}
+
+func strictParse[T any](key string, def T, parser func(string) (T, error), opts []Option) (T, error) {
+	o := newOptions(opts)
+
+	f, err := lookup(key, o)
+	if err != nil {
+		o.record(Record{Key: key, Value: fmt.Sprint(def), Defaulted: true, Err: err})
+		return def, err
 	}
 
-	value, err := parser(str)
+	if !f.ok {
+		o.record(Record{Key: key, Value: fmt.Sprint(def), Defaulted: true})
+		return def, nil
+	}
+
+	value, err := parser(f.value)
 	if err != nil {
-		return def
+		err = o.error(key, f.value, err)
+		o.record(Record{Key: key, Value: fmt.Sprint(def), Source: f.source, Defaulted: true, Err: err})
+		return def, err
 	}
 
-	return value
+	o.record(Record{Key: key, Value: f.value, Source: f.source})
+	return value, nil
+}
+
+type (
+	// The found type describes the value of an environment variable and the source it was read from.
+	found struct {
+		value  string
+		source string
+		ok     bool
+	}
+)
+
+// lookup returns the value of the environment variable with the given key from the configured source. If it is not
+// set, the value is read from the file named by the corresponding "_FILE" environment variable, if present.
+func lookup(key string, o options) (found, error) {
+	if str, source, ok := find(o.source, key, o.allowEmpty); ok {
+		return found{value: str, source: sourceName(source, key), ok: true}, nil
+	}
+
+	fileKey := key + "_FILE"
+	path, _, ok := find(o.source, fileKey, false)
+	if !ok {
+		return found{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return found{}, &Error{Key: fileKey, Value: path, Err: err}
+	}
+
+	// Files typically end with a newline that is not intended to be part of the value.
+	str := strings.TrimSuffix(strings.TrimSuffix(string(data), "\n"), "\r")
+	return found{value: str, source: "file:" + path, ok: o.allowEmpty || str != ""}, nil
+}
+
+func parseInt64(s string) (int64, error) {
+	return strconv.ParseInt(s, 10, 64)
+}
+
+func parseUint64(s string) (uint64, error) {
+	return strconv.ParseUint(s, 10, 64)
+}
+
+func parseFloat64(s string) (float64, error) {
+	return strconv.ParseFloat(s, 64)
+}
+
+func parseTime(format string) func(string) (time.Time, error) {
+	return func(s string) (time.Time, error) {
+		return time.Parse(format, s)
+	}
 }
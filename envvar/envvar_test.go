@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/davidsbond/x/envvar"
 )
@@ -183,3 +184,107 @@ func TestDuration(t *testing.T) {
 		assert.EqualValues(t, expected, actual)
 	})
 }
+
+func TestStrict(t *testing.T) {
+	tt := []struct {
+		Name  string
+		Value string
+		Parse func() (any, error)
+	}{
+		{
+			Name:  "int",
+			Value: "80a",
+			Parse: func() (any, error) {
+				return envvar.StrictInt("TEST_STRICT", 80)
+			},
+		},
+		{
+			Name:  "int64",
+			Value: "80a",
+			Parse: func() (any, error) {
+				return envvar.StrictInt64("TEST_STRICT", 80)
+			},
+		},
+		{
+			Name:  "uint64",
+			Value: "-1",
+			Parse: func() (any, error) {
+				return envvar.StrictUint64("TEST_STRICT", 80)
+			},
+		},
+		{
+			Name:  "float64",
+			Value: "abc",
+			Parse: func() (any, error) {
+				return envvar.StrictFloat64("TEST_STRICT", 80)
+			},
+		},
+		{
+			Name:  "bool",
+			Value: "yes please",
+			Parse: func() (any, error) {
+				return envvar.StrictBool("TEST_STRICT", true)
+			},
+		},
+		{
+			Name:  "time",
+			Value: "abc",
+			Parse: func() (any, error) {
+				return envvar.StrictTime("TEST_STRICT", time.DateOnly, time.Time{})
+			},
+		},
+		{
+			Name:  "duration",
+			Value: "abc",
+			Parse: func() (any, error) {
+				return envvar.StrictDuration("TEST_STRICT", time.Second)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Setenv("TEST_STRICT", "")
+			_, err := tc.Parse()
+			require.NoError(t, err)
+
+			t.Setenv("TEST_STRICT", tc.Value)
+			_, err = tc.Parse()
+
+			var envErr *envvar.Error
+			require.ErrorAs(t, err, &envErr)
+			assert.Equal(t, "TEST_STRICT", envErr.Key)
+			assert.Equal(t, tc.Value, envErr.Value)
+			assert.Contains(t, err.Error(), "TEST_STRICT")
+		})
+	}
+
+	t.Run("returns parsed value", func(t *testing.T) {
+		t.Setenv("TEST_STRICT", "42")
+		actual, err := envvar.StrictInt("TEST_STRICT", 80)
+		require.NoError(t, err)
+		assert.EqualValues(t, 42, actual)
+	})
+
+	t.Run("returns default with error", func(t *testing.T) {
+		t.Setenv("TEST_STRICT", "80a")
+		actual, err := envvar.StrictInt("TEST_STRICT", 80)
+		require.Error(t, err)
+		assert.EqualValues(t, 80, actual)
+	})
+}
+
+func TestMust(t *testing.T) {
+	t.Run("returns value", func(t *testing.T) {
+		t.Setenv("TEST_MUST", "42")
+		actual := envvar.Must(envvar.StrictInt("TEST_MUST", 80))
+		assert.EqualValues(t, 42, actual)
+	})
+
+	t.Run("panics on invalid value", func(t *testing.T) {
+		t.Setenv("TEST_MUST", "80a")
+		assert.PanicsWithError(t, `envvar: invalid value "80a" for TEST_MUST: strconv.Atoi: parsing "80a": invalid syntax`, func() {
+			envvar.Must(envvar.StrictInt("TEST_MUST", 80))
+		})
+	})
+}
@@ -0,0 +1,281 @@
+package envvar
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Load populates the fields of the struct pointed to by v from environment variables, as described by their struct
+// tags. The following tags are supported:
+//
+//   - env: The name of the environment variable, optionally followed by ",required" to indicate that the variable must
+//     be set, ",allowempty" to treat the variable as set when it is an empty string and ",sensitive" to redact its
+//     value within the Audit and errors. Fields without this tag are left unchanged.
+//   - default: The value to parse when the environment variable is not set. Fields without a default that are not set
+//     are left unchanged.
+//   - sep: The separator used to split values for slice and map fields, defaults to ",". Each element has surrounding
+//     whitespace trimmed and empty elements are ignored.
+//   - layout: The layout used to parse time.Time fields, defaults to time.RFC3339.
+//   - enum: For string fields, a comma-separated list of allowed values.
+//   - prefix: For nested struct fields, a prefix that is prepended to the environment variable names of all fields
+//     within the nested struct. Embedded structs are loaded using the prefix of their parent. Pointers to nested
+//     structs are only loaded when they have this tag, and are allocated if nil.
+//
+// Fields may be strings, booleans, integers, unsigned integers, floating point numbers, time.Duration, time.Time,
+// *url.URL, map[string]string, types implementing encoding.TextUnmarshaler (such as netip.Addr, slog.Level,
+// *regexp.Regexp and ByteSize) or slices of those types. Rather than stopping at the first problem, Load returns an
+// error joining every environment variable that is required but not set or that could not be parsed, each as an
+// *Error. Values are read from the process environment unless the From option is provided.
+func Load(v any, opts ...Option) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("envvar: cannot load into %T, must be a non-nil pointer to a struct", v)
+	}
+
+	return errors.Join(load(rv.Elem(), "", newOptions(opts))...)
+}
+
+var (
+	durationType        = reflect.TypeFor[time.Duration]()
+	timeType            = reflect.TypeFor[time.Time]()
+	urlType             = reflect.TypeFor[*url.URL]()
+	stringMapType       = reflect.TypeFor[map[string]string]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+func load(rv reflect.Value, prefix string, o options) []error {
+	var errs []error
+
+	rt := rv.Type()
+	for i := range rt.NumField() {
+		field := rt.Field(i)
+		// Unexported embedded structs are still traversed, as their exported fields are promoted.
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup("env")
+		if !ok || !field.IsExported() {
+			_, hasPrefix := field.Tag.Lookup("prefix")
+			switch {
+			case isStruct(field.Type):
+				nested := prefix
+				if !field.Anonymous {
+					nested += field.Tag.Get("prefix")
+				}
+
+				errs = append(errs, load(rv.Field(i), nested, o)...)
+			case field.Type.Kind() == reflect.Pointer && isStruct(field.Type.Elem()) && hasPrefix && !field.Anonymous:
+				if rv.Field(i).IsNil() {
+					rv.Field(i).Set(reflect.New(field.Type.Elem()))
+				}
+
+				errs = append(errs, load(rv.Field(i).Elem(), prefix+field.Tag.Get("prefix"), o)...)
+			}
+
+			continue
+		}
+
+		name, flags, _ := strings.Cut(tag, ",")
+		key := prefix + name
+
+		parser, err := parserFor(field.Type, field.Tag)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("envvar: field %s: %w", field.Name, err))
+			continue
+		}
+
+		fo := o
+		fo.allowEmpty = fo.allowEmpty || hasFlag(flags, "allowempty")
+		fo.sensitive = fo.sensitive || hasFlag(flags, "sensitive")
+
+		f, err := lookup(key, fo)
+		if err != nil {
+			fo.record(Record{Key: key, Value: fmt.Sprint(rv.Field(i).Interface()), Defaulted: true, Err: err})
+			errs = append(errs, err)
+			continue
+		}
+
+		str, source, defaulted := f.value, f.source, !f.ok
+		switch {
+		case defaulted && hasFlag(flags, "required"):
+			err = &Error{Key: key, Err: ErrRequired}
+			fo.record(Record{Key: key, Defaulted: true, Err: err})
+			errs = append(errs, err)
+			continue
+		case defaulted:
+			str, ok = field.Tag.Lookup("default")
+			if !ok {
+				fo.record(Record{Key: key, Value: fmt.Sprint(rv.Field(i).Interface()), Defaulted: true})
+				continue
+			}
+		}
+
+		value, err := parser(str)
+		if err != nil {
+			err = fo.error(key, str, err)
+			fo.record(Record{Key: key, Value: fmt.Sprint(rv.Field(i).Interface()), Source: source, Defaulted: true, Err: err})
+			errs = append(errs, err)
+			continue
+		}
+
+		fo.record(Record{Key: key, Value: str, Source: source, Defaulted: defaulted})
+		rv.Field(i).Set(value)
+	}
+
+	return errs
+}
+
+// isStruct returns true if the type is a struct that is loaded field by field, rather than parsed from a single value.
+func isStruct(rt reflect.Type) bool {
+	return rt.Kind() == reflect.Struct && rt != timeType
+}
+
+func hasFlag(flags string, flag string) bool {
+	for f := range strings.SplitSeq(flags, ",") {
+		if strings.TrimSpace(f) == flag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parserFor returns a function that parses a string into a value of the given type. The struct tag is used for
+// additional parsing options, such as the layout of time.Time values and the separator for slices.
+func parserFor(rt reflect.Type, tag reflect.StructTag) (func(string) (reflect.Value, error), error) {
+	switch rt {
+	case durationType:
+		return valueOf(rt, time.ParseDuration), nil
+	case timeType:
+		layout := tag.Get("layout")
+		if layout == "" {
+			layout = time.RFC3339
+		}
+
+		return valueOf(rt, parseTime(layout)), nil
+	case urlType:
+		return valueOf(rt, parseURL), nil
+	case stringMapType:
+		return valueOf(rt, parseStringMap(separator(tag))), nil
+	}
+
+	if parser := textParserFor(rt); parser != nil {
+		return parser, nil
+	}
+
+	switch rt.Kind() {
+	case reflect.String:
+		if enum, ok := tag.Lookup("enum"); ok {
+			return valueOf(rt, parseEnum(strings.Split(enum, ","))), nil
+		}
+
+		return valueOf(rt, func(s string) (string, error) {
+			return s, nil
+		}), nil
+	case reflect.Bool:
+		return valueOf(rt, strconv.ParseBool), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return valueOf(rt, func(s string) (int64, error) {
+			return strconv.ParseInt(s, 10, rt.Bits())
+		}), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return valueOf(rt, func(s string) (uint64, error) {
+			return strconv.ParseUint(s, 10, rt.Bits())
+		}), nil
+	case reflect.Float32, reflect.Float64:
+		return valueOf(rt, func(s string) (float64, error) {
+			return strconv.ParseFloat(s, rt.Bits())
+		}), nil
+	case reflect.Slice:
+		return sliceParserFor(rt, tag)
+	default:
+		return nil, fmt.Errorf("unsupported type %s", rt)
+	}
+}
+
+func sliceParserFor(rt reflect.Type, tag reflect.StructTag) (func(string) (reflect.Value, error), error) {
+	elem, err := parserFor(rt.Elem(), tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return sliceParser(rt, elem, separator(tag)), nil
+}
+
+// sliceParser returns a parser that splits a string using the given separator, parsing each element using the elem
+// parser. Each element has surrounding whitespace trimmed and empty elements are ignored.
+func sliceParser(rt reflect.Type, elem func(string) (reflect.Value, error), sep string) func(string) (reflect.Value, error) {
+	return func(s string) (reflect.Value, error) {
+		out := reflect.MakeSlice(rt, 0, strings.Count(s, sep)+1)
+		for part := range strings.SplitSeq(s, sep) {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+
+			value, err := elem(part)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			out = reflect.Append(out, value)
+		}
+
+		return out, nil
+	}
+}
+
+// textParserFor returns a parser for types that implement encoding.TextUnmarshaler, either directly as pointers or
+// via a pointer receiver. Returns nil if the type does not implement encoding.TextUnmarshaler.
+func textParserFor(rt reflect.Type) func(string) (reflect.Value, error) {
+	switch {
+	case rt.Kind() == reflect.Pointer && rt.Implements(textUnmarshalerType):
+		return func(s string) (reflect.Value, error) {
+			value := reflect.New(rt.Elem())
+			if err := value.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+				return reflect.Value{}, err
+			}
+
+			return value, nil
+		}
+	case reflect.PointerTo(rt).Implements(textUnmarshalerType):
+		return func(s string) (reflect.Value, error) {
+			value := reflect.New(rt)
+			if err := value.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+				return reflect.Value{}, err
+			}
+
+			return value.Elem(), nil
+		}
+	default:
+		return nil
+	}
+}
+
+func separator(tag reflect.StructTag) string {
+	if sep := tag.Get("sep"); sep != "" {
+		return sep
+	}
+
+	return ","
+}
+
+// valueOf adapts a typed parser into one returning a reflect.Value converted to the given type. This allows named
+// types, such as "type Port int", to be populated using the parser for their underlying type.
+func valueOf[T any](rt reflect.Type, parser func(string) (T, error)) func(string) (reflect.Value, error) {
+	return func(s string) (reflect.Value, error) {
+		value, err := parser(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		return reflect.ValueOf(value).Convert(rt), nil
+	}
+}
@@ -0,0 +1,119 @@
+package envvar_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidsbond/x/envvar"
+)
+
+type (
+	testConfig struct {
+		URL      string              `env:"TEST_LOAD_URL,required"`
+		Port     uint16              `env:"TEST_LOAD_PORT" default:"8080"`
+		Debug    bool                `env:"TEST_LOAD_DEBUG"`
+		Ratio    float64             `env:"TEST_LOAD_RATIO" default:"0.5"`
+		Timeout  time.Duration       `env:"TEST_LOAD_TIMEOUT" default:"30s"`
+		Start    time.Time           `env:"TEST_LOAD_START" layout:"2006-01-02"`
+		Hosts    []string            `env:"TEST_LOAD_HOSTS" sep:";"`
+		Weights  []int               `env:"TEST_LOAD_WEIGHTS"`
+		Database testDatabaseConfig  `prefix:"TEST_LOAD_DB_"`
+		Replica  *testDatabaseConfig `prefix:"TEST_LOAD_REPLICA_"`
+		Fallback *testDatabaseConfig
+		testEmbeddedConfig
+
+		Ignored string
+	}
+
+	testDatabaseConfig struct {
+		Name     string `env:"NAME" default:"postgres"`
+		MaxConns int    `env:"MAX_CONNS" default:"10"`
+	}
+
+	testEmbeddedConfig struct {
+		Level string `env:"TEST_LOAD_LEVEL" default:"info"`
+	}
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("loads configuration", func(t *testing.T) {
+		t.Setenv("TEST_LOAD_URL", "postgres://localhost")
+		t.Setenv("TEST_LOAD_PORT", "9000")
+		t.Setenv("TEST_LOAD_DEBUG", "true")
+		t.Setenv("TEST_LOAD_START", "2021-07-07")
+		t.Setenv("TEST_LOAD_HOSTS", "a; b;;c")
+		t.Setenv("TEST_LOAD_WEIGHTS", "1,2,3")
+		t.Setenv("TEST_LOAD_DB_NAME", "users")
+		t.Setenv("TEST_LOAD_REPLICA_NAME", "replica")
+		t.Setenv("TEST_LOAD_LEVEL", "debug")
+
+		expected := testConfig{
+			URL:     "postgres://localhost",
+			Port:    9000,
+			Debug:   true,
+			Ratio:   0.5,
+			Timeout: 30 * time.Second,
+			Start:   time.Date(2021, 7, 7, 0, 0, 0, 0, time.UTC),
+			Hosts:   []string{"a", "b", "c"},
+			Weights: []int{1, 2, 3},
+			Database: testDatabaseConfig{
+				Name:     "users",
+				MaxConns: 10,
+			},
+			Replica: &testDatabaseConfig{
+				Name:     "replica",
+				MaxConns: 10,
+			},
+			testEmbeddedConfig: testEmbeddedConfig{
+				Level: "debug",
+			},
+			Ignored: "unchanged",
+		}
+
+		actual := testConfig{Ignored: "unchanged"}
+		require.NoError(t, envvar.Load(&actual))
+		assert.EqualValues(t, expected, actual)
+	})
+
+	t.Run("returns every error", func(t *testing.T) {
+		t.Setenv("TEST_LOAD_URL", "")
+		t.Setenv("TEST_LOAD_PORT", "70000")
+		t.Setenv("TEST_LOAD_WEIGHTS", "1,b")
+		t.Setenv("TEST_LOAD_DB_MAX_CONNS", "ten")
+
+		var cfg testConfig
+		err := envvar.Load(&cfg)
+		require.Error(t, err)
+		require.ErrorIs(t, err, envvar.ErrRequired)
+
+		errs := err.(interface{ Unwrap() []error }).Unwrap()
+		require.Len(t, errs, 4)
+
+		keys := make([]string, len(errs))
+		for i, err := range errs {
+			var envErr *envvar.Error
+			require.ErrorAs(t, err, &envErr)
+			keys[i] = envErr.Key
+		}
+
+		assert.EqualValues(t, []string{"TEST_LOAD_URL", "TEST_LOAD_PORT", "TEST_LOAD_WEIGHTS", "TEST_LOAD_DB_MAX_CONNS"}, keys)
+		assert.Contains(t, err.Error(), "envvar: TEST_LOAD_URL: required but not set")
+	})
+
+	t.Run("rejects invalid targets", func(t *testing.T) {
+		var cfg testConfig
+		require.Error(t, envvar.Load(cfg))
+		require.Error(t, envvar.Load((*testConfig)(nil)))
+	})
+
+	t.Run("rejects unsupported types", func(t *testing.T) {
+		var cfg struct {
+			Values map[string]chan int `env:"TEST_LOAD_UNSUPPORTED"`
+		}
+
+		require.Error(t, envvar.Load(&cfg))
+	})
+}
@@ -0,0 +1,170 @@
+package envvar
+
+import (
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+type (
+	// The Source interface describes types that environment variables can be read from. By default, all functions in
+	// this package read from the OS source. Others can be provided per-call using the From option.
+	Source interface {
+		// Lookup returns the value of the environment variable with the given key. The boolean return value indicates
+		// if the environment variable is set.
+		Lookup(key string) (string, bool)
+	}
+
+	// The Map type is a Source that reads environment variables from an in-memory map. It is primarily useful in
+	// tests, where mutating the process environment prevents running in parallel.
+	Map map[string]string
+
+	// The Option type is a function that modifies how an environment variable is read.
+	Option func(*options)
+
+	options struct {
+		source     Source
+		allowEmpty bool
+		sensitive  bool
+		audit      *Audit
+	}
+
+	osSource struct{}
+
+	// The redactedError type wraps an error whose message may contain the value of a sensitive environment variable,
+	// such as those returned by the strconv package.
+	redactedError struct {
+		err   error
+		value string
+	}
+
+	layered []Source
+)
+
+var (
+	// OS is a Source that reads environment variables from the process environment.
+	OS Source = osSource{}
+
+	allowEmpty atomic.Bool
+)
+
+// From returns an Option that reads environment variables from the given Source rather than the process environment.
+func From(source Source) Option {
+	return func(o *options) {
+		o.source = source
+	}
+}
+
+// AllowEmpty returns an Option that determines whether environment variables that are set to an empty string are
+// treated as set, rather than returning the default value. This allows an empty value to deliberately override a
+// non-empty default. It takes precedence over the package-level behaviour configured via SetAllowEmpty.
+func AllowEmpty(allow bool) Option {
+	return func(o *options) {
+		o.allowEmpty = allow
+	}
+}
+
+// SetAllowEmpty sets whether environment variables that are set to an empty string are treated as set for all calls
+// that do not provide the AllowEmpty option. By default, empty values are treated as not set.
+func SetAllowEmpty(allow bool) {
+	allowEmpty.Store(allow)
+}
+
+// Layered returns a Source that reads environment variables from each of the given sources in order, returning the
+// first value that is set. Sources given first take precedence over those given later. For example, to allow the
+// process environment to override values from a .env file:
+//
+//	source := envvar.Layered(envvar.OS, dotenv)
+//
+// Unless empty values are allowed, the functions of this package skip layers in which an environment variable is set
+// to an empty string, so that "KEY=" within the process environment does not hide the value within the .env file.
+func Layered(sources ...Source) Source {
+	return layered(sources)
+}
+
+// Lookup returns the value of the given key within the Map.
+func (m Map) Lookup(key string) (string, bool) {
+	value, ok := m[key]
+	return value, ok
+}
+
+// String returns "map".
+func (m Map) String() string {
+	return "map"
+}
+
+func (osSource) String() string {
+	return "os"
+}
+
+func (osSource) Lookup(key string) (string, bool) {
+	return os.LookupEnv(key)
+}
+
+func (l layered) Lookup(key string) (string, bool) {
+	for _, source := range l {
+		if value, ok := source.Lookup(key); ok {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// find returns the value of the key within the source and the Source it was read from. Values set to an empty string
+// are treated as not set unless allowEmpty is true. For layered sources, each layer is searched in turn, so a layer
+// with an empty value does not hide the value within a later layer.
+func find(source Source, key string, allowEmpty bool) (string, Source, bool) {
+	if l, ok := source.(layered); ok {
+		for _, s := range l {
+			if value, from, ok := find(s, key, allowEmpty); ok {
+				return value, from, true
+			}
+		}
+
+		return "", nil, false
+	}
+
+	value, ok := source.Lookup(key)
+	return value, source, ok && (allowEmpty || value != "")
+}
+
+func (e *redactedError) Error() string {
+	return strings.ReplaceAll(e.err.Error(), e.value, Redacted)
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		source:     OS,
+		allowEmpty: allowEmpty.Load(),
+		audit:      defaultAudit,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+func (o options) record(r Record) {
+	r.Sensitive = o.sensitive
+	o.audit.record(r)
+}
+
+// error returns an *Error describing a value that could not be parsed, redacting the value if it is sensitive.
+func (o options) error(key string, value string, err error) *Error {
+	if o.sensitive {
+		if value != "" {
+			err = &redactedError{err: err, value: value}
+		}
+
+		value = Redacted
+	}
+
+	return &Error{Key: key, Value: value, Err: err}
+}
@@ -0,0 +1,187 @@
+package envvar_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidsbond/x/envvar"
+)
+
+func TestFrom(t *testing.T) {
+	t.Parallel()
+
+	source := envvar.Map{
+		"PORT":    "8080",
+		"TIMEOUT": "5s",
+		"INVALID": "abc",
+	}
+
+	t.Run("reads from source", func(t *testing.T) {
+		assert.EqualValues(t, 8080, envvar.Int("PORT", 0, envvar.From(source)))
+		assert.EqualValues(t, 5*time.Second, envvar.Duration("TIMEOUT", 0, envvar.From(source)))
+	})
+
+	t.Run("returns default", func(t *testing.T) {
+		assert.EqualValues(t, "default", envvar.String("MISSING", "default", envvar.From(source)))
+		assert.EqualValues(t, 10, envvar.Int("INVALID", 10, envvar.From(source)))
+	})
+
+	t.Run("returns errors", func(t *testing.T) {
+		_, err := envvar.StrictInt("INVALID", 10, envvar.From(source))
+		require.Error(t, err)
+	})
+
+	t.Run("loads configuration", func(t *testing.T) {
+		var cfg struct {
+			Port    int           `env:"PORT"`
+			Timeout time.Duration `env:"TIMEOUT"`
+		}
+
+		require.NoError(t, envvar.Load(&cfg, envvar.From(source)))
+		assert.EqualValues(t, 8080, cfg.Port)
+		assert.EqualValues(t, 5*time.Second, cfg.Timeout)
+	})
+}
+
+func TestLayered(t *testing.T) {
+	t.Parallel()
+
+	source := envvar.Layered(
+		envvar.Map{"A": "1"},
+		envvar.Map{"A": "2", "B": "2"},
+	)
+
+	t.Run("prefers earlier sources", func(t *testing.T) {
+		actual, ok := source.Lookup("A")
+		require.True(t, ok)
+		assert.EqualValues(t, "1", actual)
+	})
+
+	t.Run("falls back to later sources", func(t *testing.T) {
+		actual, ok := source.Lookup("B")
+		require.True(t, ok)
+		assert.EqualValues(t, "2", actual)
+	})
+
+	t.Run("reports missing keys", func(t *testing.T) {
+		_, ok := source.Lookup("C")
+		require.False(t, ok)
+	})
+
+	t.Run("skips empty values", func(t *testing.T) {
+		source := envvar.Layered(
+			envvar.Map{"KEY": ""},
+			envvar.Map{"KEY": "fromfile"},
+		)
+
+		assert.EqualValues(t, "fromfile", envvar.String("KEY", "def", envvar.From(source)))
+		assert.EqualValues(t, "", envvar.String("KEY", "def", envvar.From(source), envvar.AllowEmpty(true)))
+	})
+}
+
+func TestOS(t *testing.T) {
+	t.Setenv("TEST_OS", "value")
+
+	actual, ok := envvar.OS.Lookup("TEST_OS")
+	require.True(t, ok)
+	assert.EqualValues(t, "value", actual)
+}
+
+func TestAllowEmpty(t *testing.T) {
+	t.Parallel()
+
+	source := envvar.Map{
+		"EMPTY": "",
+	}
+
+	t.Run("returns default for empty values", func(t *testing.T) {
+		assert.EqualValues(t, "default", envvar.String("EMPTY", "default", envvar.From(source)))
+		assert.EqualValues(t, []string{"a"}, envvar.StringSlice("EMPTY", ",", []string{"a"}, envvar.From(source)))
+	})
+
+	t.Run("returns empty values", func(t *testing.T) {
+		assert.EqualValues(t, "", envvar.String("EMPTY", "default", envvar.From(source), envvar.AllowEmpty(true)))
+		assert.EqualValues(t, []string{}, envvar.StringSlice("EMPTY", ",", []string{"a"}, envvar.From(source), envvar.AllowEmpty(true)))
+	})
+
+	t.Run("returns default for unset values", func(t *testing.T) {
+		assert.EqualValues(t, "default", envvar.String("MISSING", "default", envvar.From(source), envvar.AllowEmpty(true)))
+	})
+
+	t.Run("returns errors for empty values that cannot be parsed", func(t *testing.T) {
+		_, err := envvar.StrictInt("EMPTY", 10, envvar.From(source), envvar.AllowEmpty(true))
+		require.Error(t, err)
+	})
+
+	t.Run("loads empty values", func(t *testing.T) {
+		cfg := struct {
+			Prefix string   `env:"EMPTY,allowempty"`
+			Hosts  []string `env:"EMPTY,allowempty"`
+			Other  string   `env:"EMPTY"`
+		}{
+			Prefix: "default",
+			Hosts:  []string{"default"},
+			Other:  "default",
+		}
+
+		require.NoError(t, envvar.Load(&cfg, envvar.From(source)))
+		assert.EqualValues(t, "", cfg.Prefix)
+		assert.EqualValues(t, []string{}, cfg.Hosts)
+		assert.EqualValues(t, "default", cfg.Other)
+	})
+}
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	source := envvar.Map{
+		"EMPTY": "",
+		"SET":   "value",
+	}
+
+	tt := []struct {
+		Name          string
+		Key           string
+		Expected      string
+		ExpectedFound bool
+	}{
+		{
+			Name:          "finds set values",
+			Key:           "SET",
+			Expected:      "value",
+			ExpectedFound: true,
+		},
+		{
+			Name:          "finds empty values",
+			Key:           "EMPTY",
+			ExpectedFound: true,
+		},
+		{
+			Name: "reports unset values",
+			Key:  "MISSING",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, ok := envvar.Lookup(tc.Key, envvar.From(source))
+			assert.Equal(t, tc.ExpectedFound, ok)
+			assert.Equal(t, tc.Expected, actual)
+		})
+	}
+}
+
+func TestSetAllowEmpty(t *testing.T) {
+	t.Setenv("TEST_ALLOW_EMPTY", "")
+
+	envvar.SetAllowEmpty(true)
+	t.Cleanup(func() {
+		envvar.SetAllowEmpty(false)
+	})
+
+	assert.EqualValues(t, "", envvar.String("TEST_ALLOW_EMPTY", "default"))
+	assert.EqualValues(t, "default", envvar.String("TEST_ALLOW_EMPTY", "default", envvar.AllowEmpty(false)))
+}
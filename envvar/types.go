@@ -0,0 +1,377 @@
+package envvar
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type (
+	// The ByteSize type represents a number of bytes that can be parsed from human-readable sizes such as "512MiB" or
+	// "1.5GB". Both decimal (KB, MB, GB, TB, PB) and binary (KiB, MiB, GiB, TiB, PiB) units are supported, units are
+	// not case-sensitive. Values without a unit are treated as bytes.
+	ByteSize uint64
+)
+
+var byteUnits = map[string]uint64{
+	"":    1,
+	"b":   1,
+	"kb":  1e3,
+	"mb":  1e6,
+	"gb":  1e9,
+	"tb":  1e12,
+	"pb":  1e15,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+	"pib": 1 << 50,
+}
+
+// UnmarshalText parses a human-readable byte size.
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+
+	if i < 0 {
+		i = len(s)
+	}
+
+	number, unit := s[:i], strings.ToLower(strings.TrimSpace(s[i:]))
+
+	multiplier, ok := byteUnits[unit]
+	if !ok {
+		return fmt.Errorf("unknown unit %q", s[i:])
+	}
+
+	if !strings.Contains(number, ".") {
+		n, err := strconv.ParseUint(number, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		if n > math.MaxUint64/multiplier {
+			return fmt.Errorf("size %q overflows", s)
+		}
+
+		*b = ByteSize(n * multiplier)
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return err
+	}
+
+	size := f * float64(multiplier)
+	if size >= math.MaxUint64 {
+		return fmt.Errorf("size %q overflows", s)
+	}
+
+	*b = ByteSize(size)
+	return nil
+}
+
+// URL returns the value of the specified environment variable as a *url.URL. The URL must contain a scheme. Returns
+// the specified default value when the environment variable is not set or cannot be parsed.
+func URL(key string, def *url.URL, opts ...Option) *url.URL {
+	return parse(key, def, parseURL, opts)
+}
+
+// StrictURL returns the value of the specified environment variable as a *url.URL. The URL must contain a scheme.
+// Returns the specified default value when the environment variable is not set. Returns the default value and an
+// *Error if the value cannot be parsed.
+func StrictURL(key string, def *url.URL, opts ...Option) (*url.URL, error) {
+	return strictParse(key, def, parseURL, opts)
+}
+
+// Addr returns the value of the specified environment variable as a netip.Addr. Returns the specified default value
+// when the environment variable is not set or cannot be parsed.
+func Addr(key string, def netip.Addr, opts ...Option) netip.Addr {
+	return parse(key, def, netip.ParseAddr, opts)
+}
+
+// StrictAddr returns the value of the specified environment variable as a netip.Addr. Returns the specified default
+// value when the environment variable is not set. Returns the default value and an *Error if the value cannot be
+// parsed.
+func StrictAddr(key string, def netip.Addr, opts ...Option) (netip.Addr, error) {
+	return strictParse(key, def, netip.ParseAddr, opts)
+}
+
+// Prefix returns the value of the specified environment variable as a netip.Prefix, such as "10.0.0.0/8". Returns the
+// specified default value when the environment variable is not set or cannot be parsed.
+func Prefix(key string, def netip.Prefix, opts ...Option) netip.Prefix {
+	return parse(key, def, netip.ParsePrefix, opts)
+}
+
+// StrictPrefix returns the value of the specified environment variable as a netip.Prefix, such as "10.0.0.0/8".
+// Returns the specified default value when the environment variable is not set. Returns the default value and an
+// *Error if the value cannot be parsed.
+func StrictPrefix(key string, def netip.Prefix, opts ...Option) (netip.Prefix, error) {
+	return strictParse(key, def, netip.ParsePrefix, opts)
+}
+
+// Bytes returns the value of the specified environment variable as a ByteSize, parsed from a human-readable size such
+// as "512MiB". Returns the specified default value when the environment variable is not set or cannot be parsed.
+func Bytes(key string, def ByteSize, opts ...Option) ByteSize {
+	return Text(key, def, opts...)
+}
+
+// StrictBytes returns the value of the specified environment variable as a ByteSize, parsed from a human-readable size
+// such as "512MiB". Returns the specified default value when the environment variable is not set. Returns the default
+// value and an *Error if the value cannot be parsed.
+func StrictBytes(key string, def ByteSize, opts ...Option) (ByteSize, error) {
+	return StrictText(key, def, opts...)
+}
+
+// Enum returns the value of the specified environment variable, which must be one of the allowed values. Returns the
+// specified default value when the environment variable is not set or is not an allowed value.
+func Enum[T ~string](key string, def T, allowed []T, opts ...Option) T {
+	return parse(key, def, parseEnum(allowed), opts)
+}
+
+// StrictEnum returns the value of the specified environment variable, which must be one of the allowed values. Returns
+// the specified default value when the environment variable is not set. Returns the default value and an *Error if the
+// value is not an allowed value.
+func StrictEnum[T ~string](key string, def T, allowed []T, opts ...Option) (T, error) {
+	return strictParse(key, def, parseEnum(allowed), opts)
+}
+
+// StringMap returns the value of the specified environment variable as a map of strings. Entries are separated by the
+// specified separator and each entry is a key and value separated by "=", such as "a=1,b=2". Surrounding whitespace is
+// trimmed and empty entries are ignored. Returns the specified default value when the environment variable is not set
+// or cannot be parsed.
+func StringMap(key string, sep string, def map[string]string, opts ...Option) map[string]string {
+	return parse(key, def, parseStringMap(sep), opts)
+}
+
+// StrictStringMap returns the value of the specified environment variable as a map of strings. Entries are separated
+// by the specified separator and each entry is a key and value separated by "=", such as "a=1,b=2". Surrounding
+// whitespace is trimmed and empty entries are ignored. Returns the specified default value when the environment
+// variable is not set. Returns the default value and an *Error if the value cannot be parsed.
+func StrictStringMap(key string, sep string, def map[string]string, opts ...Option) (map[string]string, error) {
+	return strictParse(key, def, parseStringMap(sep), opts)
+}
+
+// Regexp returns the value of the specified environment variable as a compiled *regexp.Regexp. Returns the specified
+// default value when the environment variable is not set or cannot be compiled.
+func Regexp(key string, def *regexp.Regexp, opts ...Option) *regexp.Regexp {
+	return parse(key, def, regexp.Compile, opts)
+}
+
+// StrictRegexp returns the value of the specified environment variable as a compiled *regexp.Regexp. Returns the
+// specified default value when the environment variable is not set. Returns the default value and an *Error if the
+// value cannot be compiled.
+func StrictRegexp(key string, def *regexp.Regexp, opts ...Option) (*regexp.Regexp, error) {
+	return strictParse(key, def, regexp.Compile, opts)
+}
+
+// Level returns the value of the specified environment variable as a slog.Level, such as "debug" or "WARN+2". Returns
+// the specified default value when the environment variable is not set or cannot be parsed.
+func Level(key string, def slog.Level, opts ...Option) slog.Level {
+	return Text(key, def, opts...)
+}
+
+// StrictLevel returns the value of the specified environment variable as a slog.Level, such as "debug" or "WARN+2".
+// Returns the specified default value when the environment variable is not set. Returns the default value and an
+// *Error if the value cannot be parsed.
+func StrictLevel(key string, def slog.Level, opts ...Option) (slog.Level, error) {
+	return StrictText(key, def, opts...)
+}
+
+// Text returns the value of the specified environment variable parsed using the encoding.TextUnmarshaler
+// implementation of T. Returns the specified default value when the environment variable is not set or cannot be
+// parsed.
+func Text[T any, PT interface {
+	*T
+	encoding.TextUnmarshaler
+}](key string, def T, opts ...Option) T {
+	return parse(key, def, parseText[T, PT], opts)
+}
+
+// StrictText returns the value of the specified environment variable parsed using the encoding.TextUnmarshaler
+// implementation of T. Returns the specified default value when the environment variable is not set. Returns the
+// default value and an *Error if the value cannot be parsed.
+func StrictText[T any, PT interface {
+	*T
+	encoding.TextUnmarshaler
+}](key string, def T, opts ...Option) (T, error) {
+	return strictParse(key, def, parseText[T, PT], opts)
+}
+
+func parseText[T any, PT interface {
+	*T
+	encoding.TextUnmarshaler
+}](s string) (T, error) {
+	var value T
+	err := PT(&value).UnmarshalText([]byte(s))
+	return value, err
+}
+
+func parseURL(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme == "" {
+		return nil, errors.New("missing scheme")
+	}
+
+	return u, nil
+}
+
+func parseEnum[T ~string](allowed []T) func(string) (T, error) {
+	return func(s string) (T, error) {
+		if !slices.Contains(allowed, T(s)) {
+			return "", fmt.Errorf("must be one of %v", allowed)
+		}
+
+		return T(s), nil
+	}
+}
+
+func parseStringMap(sep string) func(string) (map[string]string, error) {
+	return func(s string) (map[string]string, error) {
+		out := make(map[string]string)
+		for entry := range strings.SplitSeq(s, sep) {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+
+			k, v, ok := strings.Cut(entry, "=")
+			if !ok {
+				return nil, fmt.Errorf("entry %q is missing '='", entry)
+			}
+
+			out[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+
+		return out, nil
+	}
+}
+
+type (
+	// The Integer interface is a constraint that permits any integer type.
+	Integer interface {
+		~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+	}
+
+	// The Float interface is a constraint that permits any floating point type.
+	Float interface {
+		~float32 | ~float64
+	}
+)
+
+// Number returns the value of the specified environment variable as a number of type T. Values that overflow T are
+// treated as invalid. Returns the specified default value when the environment variable is not set or cannot be
+// parsed.
+func Number[T Integer | Float](key string, def T, opts ...Option) T {
+	return parse(key, def, typedParser[T](), opts)
+}
+
+// StrictNumber returns the value of the specified environment variable as a number of type T. Values that overflow T
+// are treated as invalid. Returns the specified default value when the environment variable is not set. Returns the
+// default value and an *Error if the value cannot be parsed.
+func StrictNumber[T Integer | Float](key string, def T, opts ...Option) (T, error) {
+	return strictParse(key, def, typedParser[T](), opts)
+}
+
+// Between returns the value of the specified environment variable as a number of type T that must be within the
+// inclusive range of lower and upper. Returns the specified default value when the environment variable is not set,
+// cannot be parsed or is out of range.
+func Between[T Integer | Float](key string, lower, upper T, def T, opts ...Option) T {
+	return parse(key, def, rangeParser(lower, upper), opts)
+}
+
+// StrictBetween returns the value of the specified environment variable as a number of type T that must be within the
+// inclusive range of lower and upper. Returns the specified default value when the environment variable is not set.
+// Returns the default value and an *Error if the value cannot be parsed or is out of range.
+func StrictBetween[T Integer | Float](key string, lower, upper T, def T, opts ...Option) (T, error) {
+	return strictParse(key, def, rangeParser(lower, upper), opts)
+}
+
+// Slice returns the value of the specified environment variable as a slice of T, split using the specified separator.
+// Each element is parsed using the same rules as the corresponding field type for Load. Surrounding whitespace is
+// trimmed from each element and empty elements are ignored. Returns the specified default value when the environment
+// variable is not set or any element cannot be parsed. Panics if elements of type T cannot be parsed.
+func Slice[T any](key string, sep string, def []T, opts ...Option) []T {
+	return parse(key, def, sliceOf[T](sep), opts)
+}
+
+// StrictSlice returns the value of the specified environment variable as a slice of T, split using the specified
+// separator. Each element is parsed using the same rules as the corresponding field type for Load. Surrounding
+// whitespace is trimmed from each element and empty elements are ignored. Returns the specified default value when
+// the environment variable is not set. Returns the default value and an *Error if any element cannot be parsed. Panics
+// if elements of type T cannot be parsed.
+func StrictSlice[T any](key string, sep string, def []T, opts ...Option) ([]T, error) {
+	return strictParse(key, def, sliceOf[T](sep), opts)
+}
+
+func rangeParser[T Integer | Float](lower, upper T) func(string) (T, error) {
+	parser := typedParser[T]()
+
+	return func(s string) (T, error) {
+		value, err := parser(s)
+		if err != nil {
+			return value, err
+		}
+
+		if value < lower || value > upper {
+			return value, fmt.Errorf("must be between %v and %v", lower, upper)
+		}
+
+		return value, nil
+	}
+}
+
+func sliceOf[T any](sep string) func(string) ([]T, error) {
+	rt := reflect.TypeFor[T]()
+
+	elem, err := parserFor(rt, "")
+	if err != nil {
+		panic(fmt.Sprintf("envvar: cannot parse slice elements: %v", err))
+	}
+
+	parser := sliceParser(reflect.SliceOf(rt), elem, sep)
+	return func(s string) ([]T, error) {
+		value, err := parser(s)
+		if err != nil {
+			return nil, err
+		}
+
+		return value.Interface().([]T), nil
+	}
+}
+
+// typedParser returns a parser for T using the same rules as the corresponding field type for Load. Panics if values
+// of type T cannot be parsed.
+func typedParser[T any]() func(string) (T, error) {
+	parser, err := parserFor(reflect.TypeFor[T](), "")
+	if err != nil {
+		panic(fmt.Sprintf("envvar: %v", err))
+	}
+
+	return func(s string) (T, error) {
+		value, err := parser(s)
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+
+		return value.Interface().(T), nil
+	}
+}
@@ -0,0 +1,404 @@
+package envvar_test
+
+import (
+	"log/slog"
+	"net/netip"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidsbond/x/envvar"
+)
+
+func TestURL(t *testing.T) {
+	t.Parallel()
+
+	def := &url.URL{Scheme: "http", Host: "localhost"}
+	source := envvar.From(envvar.Map{
+		"VALID":   "postgres://user@db:5432/app",
+		"INVALID": "localhost",
+	})
+
+	actual := envvar.URL("VALID", def, source)
+	assert.EqualValues(t, "postgres://user@db:5432/app", actual.String())
+
+	actual, err := envvar.StrictURL("INVALID", def, source)
+	require.Error(t, err)
+	assert.Equal(t, def, actual)
+}
+
+func TestAddr(t *testing.T) {
+	t.Parallel()
+
+	def := netip.MustParseAddr("127.0.0.1")
+	source := envvar.From(envvar.Map{
+		"VALID":   "::1",
+		"INVALID": "abc",
+	})
+
+	assert.EqualValues(t, netip.IPv6Loopback(), envvar.Addr("VALID", def, source))
+	assert.EqualValues(t, def, envvar.Addr("INVALID", def, source))
+
+	_, err := envvar.StrictAddr("INVALID", def, source)
+	require.Error(t, err)
+}
+
+func TestPrefix(t *testing.T) {
+	t.Parallel()
+
+	def := netip.MustParsePrefix("0.0.0.0/0")
+	source := envvar.From(envvar.Map{
+		"VALID":   "10.0.0.0/8",
+		"INVALID": "10.0.0.0",
+	})
+
+	assert.EqualValues(t, netip.MustParsePrefix("10.0.0.0/8"), envvar.Prefix("VALID", def, source))
+	assert.EqualValues(t, def, envvar.Prefix("INVALID", def, source))
+
+	_, err := envvar.StrictPrefix("INVALID", def, source)
+	require.Error(t, err)
+}
+
+func TestBytes(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Name        string
+		Value       string
+		Expected    envvar.ByteSize
+		ExpectError bool
+	}{
+		{
+			Name:     "parses bytes",
+			Value:    "1024",
+			Expected: 1024,
+		},
+		{
+			Name:     "parses binary units",
+			Value:    "512MiB",
+			Expected: 512 << 20,
+		},
+		{
+			Name:     "parses decimal units",
+			Value:    "2 gb",
+			Expected: 2e9,
+		},
+		{
+			Name:     "parses fractions",
+			Value:    "1.5KiB",
+			Expected: 1536,
+		},
+		{
+			Name:        "rejects unknown units",
+			Value:       "10XB",
+			ExpectError: true,
+		},
+		{
+			Name:        "rejects overflow",
+			Value:       "20000000PiB",
+			ExpectError: true,
+		},
+		{
+			Name:        "rejects negative values",
+			Value:       "-1",
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := envvar.StrictBytes("SIZE", 1, envvar.From(envvar.Map{"SIZE": tc.Value}))
+			if tc.ExpectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.EqualValues(t, tc.Expected, actual)
+		})
+	}
+}
+
+func TestEnum(t *testing.T) {
+	t.Parallel()
+
+	type mode string
+
+	allowed := []mode{"dev", "prod"}
+	source := envvar.From(envvar.Map{
+		"VALID":   "prod",
+		"INVALID": "staging",
+	})
+
+	assert.EqualValues(t, "prod", envvar.Enum("VALID", mode("dev"), allowed, source))
+	assert.EqualValues(t, "dev", envvar.Enum("INVALID", mode("dev"), allowed, source))
+
+	_, err := envvar.StrictEnum("INVALID", mode("dev"), allowed, source)
+	require.Error(t, err)
+}
+
+func TestStringMap(t *testing.T) {
+	t.Parallel()
+
+	def := map[string]string{"a": "1"}
+	source := envvar.From(envvar.Map{
+		"VALID":   "a=1, b = 2,,c=x=y",
+		"INVALID": "a=1,b",
+	})
+
+	assert.EqualValues(t, map[string]string{"a": "1", "b": "2", "c": "x=y"}, envvar.StringMap("VALID", ",", def, source))
+	assert.EqualValues(t, def, envvar.StringMap("INVALID", ",", def, source))
+
+	_, err := envvar.StrictStringMap("INVALID", ",", def, source)
+	require.Error(t, err)
+}
+
+func TestRegexp(t *testing.T) {
+	t.Parallel()
+
+	def := regexp.MustCompile("^default$")
+	source := envvar.From(envvar.Map{
+		"VALID":   "^[a-z]+$",
+		"INVALID": "[",
+	})
+
+	assert.True(t, envvar.Regexp("VALID", def, source).MatchString("abc"))
+	assert.Equal(t, def, envvar.Regexp("INVALID", def, source))
+
+	_, err := envvar.StrictRegexp("INVALID", def, source)
+	require.Error(t, err)
+}
+
+func TestLevel(t *testing.T) {
+	t.Parallel()
+
+	source := envvar.From(envvar.Map{
+		"VALID":   "warn+2",
+		"INVALID": "loud",
+	})
+
+	assert.EqualValues(t, slog.LevelWarn+2, envvar.Level("VALID", slog.LevelInfo, source))
+	assert.EqualValues(t, slog.LevelInfo, envvar.Level("INVALID", slog.LevelInfo, source))
+
+	_, err := envvar.StrictLevel("INVALID", slog.LevelInfo, source)
+	require.Error(t, err)
+}
+
+func TestText(t *testing.T) {
+	t.Parallel()
+
+	source := envvar.From(envvar.Map{
+		"VALID": "192.168.0.1",
+	})
+
+	actual := envvar.Text("VALID", netip.Addr{}, source)
+	assert.EqualValues(t, netip.MustParseAddr("192.168.0.1"), actual)
+}
+
+func TestFileSecrets(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "password")
+	require.NoError(t, os.WriteFile(path, []byte("hunter2\n"), 0o600))
+
+	t.Run("reads from file", func(t *testing.T) {
+		source := envvar.From(envvar.Map{"DB_PASSWORD_FILE": path})
+		assert.EqualValues(t, "hunter2", envvar.String("DB_PASSWORD", "", source))
+	})
+
+	t.Run("prefers environment variable", func(t *testing.T) {
+		source := envvar.From(envvar.Map{"DB_PASSWORD": "direct", "DB_PASSWORD_FILE": path})
+		assert.EqualValues(t, "direct", envvar.String("DB_PASSWORD", "", source))
+	})
+
+	t.Run("returns errors for missing files", func(t *testing.T) {
+		source := envvar.From(envvar.Map{"DB_PASSWORD_FILE": filepath.Join(dir, "missing")})
+
+		_, err := envvar.StrictInt("DB_PASSWORD", 0, source)
+
+		var envErr *envvar.Error
+		require.ErrorAs(t, err, &envErr)
+		assert.Equal(t, "DB_PASSWORD_FILE", envErr.Key)
+	})
+
+	t.Run("loads from file", func(t *testing.T) {
+		var cfg struct {
+			Password string `env:"DB_PASSWORD,required"`
+		}
+
+		require.NoError(t, envvar.Load(&cfg, envvar.From(envvar.Map{"DB_PASSWORD_FILE": path})))
+		assert.EqualValues(t, "hunter2", cfg.Password)
+	})
+}
+
+func TestLoadTypes(t *testing.T) {
+	t.Parallel()
+
+	source := envvar.Map{
+		"URL":    "https://example.com",
+		"ADDR":   "10.0.0.1",
+		"PREFIX": "10.0.0.0/8",
+		"SIZE":   "64MiB",
+		"MODE":   "prod",
+		"LABELS": "a=1;b=2",
+		"REGEXP": "^a+$",
+		"LEVEL":  "debug",
+		"ADDRS":  "10.0.0.1, 10.0.0.2",
+	}
+
+	var cfg struct {
+		URL    *url.URL          `env:"URL"`
+		Addr   netip.Addr        `env:"ADDR"`
+		Prefix netip.Prefix      `env:"PREFIX"`
+		Size   envvar.ByteSize   `env:"SIZE"`
+		Mode   string            `env:"MODE" enum:"dev,prod"`
+		Labels map[string]string `env:"LABELS" sep:";"`
+		Regexp *regexp.Regexp    `env:"REGEXP"`
+		Level  slog.Level        `env:"LEVEL"`
+		Addrs  []netip.Addr      `env:"ADDRS"`
+	}
+
+	require.NoError(t, envvar.Load(&cfg, envvar.From(source)))
+	assert.EqualValues(t, "https://example.com", cfg.URL.String())
+	assert.EqualValues(t, netip.MustParseAddr("10.0.0.1"), cfg.Addr)
+	assert.EqualValues(t, netip.MustParsePrefix("10.0.0.0/8"), cfg.Prefix)
+	assert.EqualValues(t, 64<<20, cfg.Size)
+	assert.EqualValues(t, "prod", cfg.Mode)
+	assert.EqualValues(t, map[string]string{"a": "1", "b": "2"}, cfg.Labels)
+	assert.True(t, cfg.Regexp.MatchString("aaa"))
+	assert.EqualValues(t, slog.LevelDebug, cfg.Level)
+	assert.EqualValues(t, []netip.Addr{netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("10.0.0.2")}, cfg.Addrs)
+
+	source["MODE"] = "staging"
+	require.Error(t, envvar.Load(&cfg, envvar.From(source)))
+}
+
+func TestNumber(t *testing.T) {
+	t.Parallel()
+
+	source := envvar.From(envvar.Map{
+		"INT8":     "127",
+		"OVERFLOW": "128",
+		"NEGATIVE": "-1",
+		"FLOAT32":  "1.5",
+		"INVALID":  "abc",
+	})
+
+	t.Run("parses values", func(t *testing.T) {
+		assert.EqualValues(t, int8(127), envvar.Number("INT8", int8(0), source))
+		assert.EqualValues(t, uint16(127), envvar.Number("INT8", uint16(0), source))
+		assert.EqualValues(t, float32(1.5), envvar.Number("FLOAT32", float32(0), source))
+	})
+
+	t.Run("detects overflow", func(t *testing.T) {
+		actual, err := envvar.StrictNumber("OVERFLOW", int8(1), source)
+		require.Error(t, err)
+		assert.EqualValues(t, 1, actual)
+
+		_, err = envvar.StrictNumber("NEGATIVE", uint32(1), source)
+		require.Error(t, err)
+	})
+
+	t.Run("returns default", func(t *testing.T) {
+		assert.EqualValues(t, int32(10), envvar.Number("INVALID", int32(10), source))
+		assert.EqualValues(t, int32(10), envvar.Number("MISSING", int32(10), source))
+	})
+}
+
+func TestNumber_Types(t *testing.T) {
+	t.Parallel()
+
+	type Named int
+
+	// Each type permitted by the Integer and Float constraints must be supported.
+	tt := []struct {
+		Name string
+		Test func(t *testing.T)
+	}{
+		{Name: "int", Test: testNumberType[int]},
+		{Name: "int8", Test: testNumberType[int8]},
+		{Name: "int16", Test: testNumberType[int16]},
+		{Name: "int32", Test: testNumberType[int32]},
+		{Name: "int64", Test: testNumberType[int64]},
+		{Name: "uint", Test: testNumberType[uint]},
+		{Name: "uint8", Test: testNumberType[uint8]},
+		{Name: "uint16", Test: testNumberType[uint16]},
+		{Name: "uint32", Test: testNumberType[uint32]},
+		{Name: "uint64", Test: testNumberType[uint64]},
+		{Name: "uintptr", Test: testNumberType[uintptr]},
+		{Name: "float32", Test: testNumberType[float32]},
+		{Name: "float64", Test: testNumberType[float64]},
+		{Name: "named", Test: testNumberType[Named]},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, tc.Test)
+	}
+}
+
+func testNumberType[T envvar.Integer | envvar.Float](t *testing.T) {
+	source := envvar.From(envvar.Map{"VALUE": "42"})
+
+	actual, err := envvar.StrictNumber("VALUE", T(0), source)
+	require.NoError(t, err)
+	assert.EqualValues(t, 42, actual)
+
+	actual, err = envvar.StrictBetween("VALUE", T(1), T(100), T(0), source)
+	require.NoError(t, err)
+	assert.EqualValues(t, 42, actual)
+
+	assert.EqualValues(t, 42, envvar.Between("VALUE", T(1), T(100), T(0), source))
+}
+
+func TestBetween(t *testing.T) {
+	t.Parallel()
+
+	source := envvar.From(envvar.Map{
+		"PORT":    "8080",
+		"INVALID": "70000",
+	})
+
+	assert.EqualValues(t, 8080, envvar.Between("PORT", 1, 65535, 80, source))
+	assert.EqualValues(t, 80, envvar.Between("INVALID", 1, 65535, 80, source))
+
+	_, err := envvar.StrictBetween("INVALID", 1, 65535, 80, source)
+	require.ErrorContains(t, err, "must be between 1 and 65535")
+}
+
+func TestSlice(t *testing.T) {
+	t.Parallel()
+
+	source := envvar.From(envvar.Map{
+		"INTS":      " 1, 2,,3 ",
+		"DURATIONS": "1s;1m",
+		"ADDRS":     "10.0.0.1,::1",
+		"INVALID":   "1,b",
+	})
+
+	t.Run("parses elements", func(t *testing.T) {
+		assert.EqualValues(t, []int{1, 2, 3}, envvar.Slice("INTS", ",", []int(nil), source))
+		assert.EqualValues(t, []time.Duration{time.Second, time.Minute}, envvar.Slice("DURATIONS", ";", []time.Duration(nil), source))
+		assert.EqualValues(t, []netip.Addr{netip.MustParseAddr("10.0.0.1"), netip.IPv6Loopback()}, envvar.Slice("ADDRS", ",", []netip.Addr(nil), source))
+	})
+
+	t.Run("returns default", func(t *testing.T) {
+		def := []int{4}
+		assert.EqualValues(t, def, envvar.Slice("INVALID", ",", def, source))
+
+		_, err := envvar.StrictSlice("INVALID", ",", def, source)
+		require.Error(t, err)
+	})
+
+	t.Run("panics on unsupported types", func(t *testing.T) {
+		assert.Panics(t, func() {
+			envvar.Slice("INTS", ",", []chan int(nil), source)
+		})
+	})
+}
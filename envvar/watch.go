@@ -0,0 +1,217 @@
+package envvar
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+)
+
+type (
+	// The Watcher type polls a .env file for changes, parsing its contents into a value of type T whenever it changes.
+	// Subscribers are notified only when the parsed value differs from the previous one, so changes to comments,
+	// formatting or unused variables do not cause notifications.
+	Watcher[T any] struct {
+		path     string
+		interval time.Duration
+		parse    func(Source) (T, error)
+
+		mux         sync.RWMutex
+		value       T
+		contents    []byte
+		onChange    []func(T)
+		onError     []func(error)
+		subscribers []chan T
+		closed      bool
+		unreadable  bool
+	}
+)
+
+// NewWatcher returns a new Watcher for the .env file at the given path that checks for changes at the specified
+// interval. The parse function is called with a Source containing the variables within the file each time its
+// contents change and should use the functions in this package, via the From option, to produce the value. To allow
+// the process environment to take precedence over the file, use a Layered Source within the parse function. The file
+// is read and parsed once before returning, any error doing so is returned.
+func NewWatcher[T any](path string, interval time.Duration, parse func(Source) (T, error)) (*Watcher[T], error) {
+	w := &Watcher[T]{
+		path:     path,
+		interval: interval,
+		parse:    parse,
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := w.load(contents)
+	if err != nil {
+		return nil, err
+	}
+
+	w.value = value
+	w.contents = contents
+
+	return w, nil
+}
+
+// Value returns the most recently parsed value.
+func (w *Watcher[T]) Value() T {
+	w.mux.RLock()
+	defer w.mux.RUnlock()
+
+	return w.value
+}
+
+// OnChange registers a function that is called with the new value each time it changes. Functions are called
+// sequentially from the goroutine calling Run and should not block.
+func (w *Watcher[T]) OnChange(fn func(T)) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	w.onChange = append(w.onChange, fn)
+}
+
+// OnError registers a function that is called when the file cannot be read or parsed. When this occurs, the previous
+// value is kept. Functions are called sequentially from the goroutine calling Run and should not block.
+func (w *Watcher[T]) OnError(fn func(error)) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	w.onError = append(w.onError, fn)
+}
+
+// Subscribe returns a channel that receives the new value each time it changes. The channel has a buffer of one and
+// only ever holds the latest value, so slow subscribers receive the most recent value rather than every intermediate
+// one. The channel is closed when Run returns.
+func (w *Watcher[T]) Subscribe() <-chan T {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	ch := make(chan T, 1)
+	if w.closed {
+		close(ch)
+		return ch
+	}
+
+	w.subscribers = append(w.subscribers, ch)
+	return ch
+}
+
+// Run polls the file for changes until the provided context is cancelled. This method blocks.
+func (w *Watcher[T]) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	defer w.close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.reload()
+		}
+	}
+}
+
+func (w *Watcher[T]) reload() {
+	contents, err := os.ReadFile(w.path)
+	if err != nil {
+		// Errors reading the file are only reported when they first occur, rather than on every poll.
+		if !w.unreadable {
+			w.publishError(err)
+		}
+
+		w.unreadable = true
+		return
+	}
+
+	w.unreadable = false
+
+	w.mux.Lock()
+	unchanged := bytes.Equal(contents, w.contents)
+	w.contents = contents
+	w.mux.Unlock()
+
+	// The contents are recorded before parsing, so that invalid contents are only reported once per change.
+	if unchanged {
+		return
+	}
+
+	value, err := w.load(contents)
+	if err != nil {
+		w.publishError(err)
+		return
+	}
+
+	w.mux.Lock()
+	changed := !reflect.DeepEqual(value, w.value)
+	if changed {
+		w.value = value
+	}
+	w.mux.Unlock()
+
+	if changed {
+		w.publish(value)
+	}
+}
+
+func (w *Watcher[T]) load(contents []byte) (T, error) {
+	source, err := ParseDotEnv(bytes.NewReader(contents))
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return w.parse(source)
+}
+
+func (w *Watcher[T]) publish(value T) {
+	w.mux.RLock()
+	onChange := w.onChange
+	subscribers := w.subscribers
+	w.mux.RUnlock()
+
+	for _, fn := range onChange {
+		fn(value)
+	}
+
+	for _, ch := range subscribers {
+		// The channel is used in a "last write wins" fashion. If it already holds a value the subscriber has not
+		// read, it is drained before writing the new one.
+		select {
+		case ch <- value:
+		default:
+			select {
+			case <-ch:
+			default:
+			}
+
+			ch <- value
+		}
+	}
+}
+
+func (w *Watcher[T]) publishError(err error) {
+	w.mux.RLock()
+	onError := w.onError
+	w.mux.RUnlock()
+
+	for _, fn := range onError {
+		fn(err)
+	}
+}
+
+func (w *Watcher[T]) close() {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	w.closed = true
+	for _, ch := range w.subscribers {
+		close(ch)
+	}
+
+	w.subscribers = nil
+}
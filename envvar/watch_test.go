@@ -0,0 +1,135 @@
+package envvar_test
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidsbond/x/envvar"
+)
+
+type (
+	testWatchConfig struct {
+		Level slog.Level `env:"LOG_LEVEL" default:"info"`
+		Limit int        `env:"RATE_LIMIT" default:"10"`
+	}
+)
+
+func TestWatcher(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), ".env")
+	// Files are replaced atomically, like mounted ConfigMaps, so that partially written files are never read.
+	write := func(contents string) {
+		tmp := path + ".tmp"
+		require.NoError(t, os.WriteFile(tmp, []byte(contents), 0o600))
+		require.NoError(t, os.Rename(tmp, path))
+	}
+
+	write("LOG_LEVEL=debug\n")
+
+	const interval = 10 * time.Millisecond
+
+	w, err := envvar.NewWatcher(path, interval, func(source envvar.Source) (testWatchConfig, error) {
+		var cfg testWatchConfig
+		err := envvar.Load(&cfg, envvar.From(source))
+		return cfg, err
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, testWatchConfig{Level: slog.LevelDebug, Limit: 10}, w.Value())
+
+	var (
+		changes = make(chan testWatchConfig, 10)
+		errs    = make(chan error, 10)
+	)
+
+	w.OnChange(func(cfg testWatchConfig) {
+		changes <- cfg
+	})
+
+	w.OnError(func(err error) {
+		errs <- err
+	})
+
+	updates := w.Subscribe()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.Run(ctx)
+	}()
+
+	t.Run("publishes changed values", func(t *testing.T) {
+		write("LOG_LEVEL=warn\nRATE_LIMIT=20\n")
+
+		expected := testWatchConfig{Level: slog.LevelWarn, Limit: 20}
+		assert.EqualValues(t, expected, <-changes)
+		assert.EqualValues(t, expected, <-updates)
+		assert.EqualValues(t, expected, w.Value())
+	})
+
+	t.Run("ignores changes that do not affect values", func(t *testing.T) {
+		write("# comment\nLOG_LEVEL=warn\nRATE_LIMIT=20\nUNUSED=1\n")
+
+		// Wait for several polls so that the change is observed on its own.
+		time.Sleep(5 * interval)
+		assert.Empty(t, changes)
+		assert.Empty(t, updates)
+
+		write("# comment\nLOG_LEVEL=error\nRATE_LIMIT=20\nUNUSED=1\n")
+
+		assert.EqualValues(t, slog.LevelError, (<-changes).Level)
+		assert.EqualValues(t, slog.LevelError, (<-updates).Level)
+	})
+
+	t.Run("keeps previous value on error", func(t *testing.T) {
+		write("RATE_LIMIT=lots\n")
+
+		require.Error(t, <-errs)
+		assert.EqualValues(t, slog.LevelError, w.Value().Level)
+	})
+
+	t.Run("reports each error once", func(t *testing.T) {
+		time.Sleep(5 * interval)
+		assert.Empty(t, errs)
+
+		write("RATE_LIMIT=many\n")
+		require.Error(t, <-errs)
+	})
+
+	t.Run("closes subscriptions when stopped", func(t *testing.T) {
+		cancel()
+		<-done
+
+		_, ok := <-updates
+		assert.False(t, ok)
+	})
+}
+
+func TestNewWatcher(t *testing.T) {
+	t.Parallel()
+
+	parse := func(source envvar.Source) (string, error) {
+		return envvar.String("KEY", "", envvar.From(source)), nil
+	}
+
+	t.Run("returns error for missing file", func(t *testing.T) {
+		_, err := envvar.NewWatcher(filepath.Join(t.TempDir(), "missing"), time.Second, parse)
+		require.Error(t, err)
+	})
+
+	t.Run("returns error for invalid file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".env")
+		require.NoError(t, os.WriteFile(path, []byte("KEY"), 0o600))
+
+		_, err := envvar.NewWatcher(path, time.Second, parse)
+		require.Error(t, err)
+	})
+}
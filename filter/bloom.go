@@ -0,0 +1,167 @@
+package filter
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"math"
+)
+
+type (
+	// The Bloom type is a probabilistic set that uses far less memory than a set.Set. Contains never returns false for
+	// a value that has been added, but may return true for one that has not, at a rate determined when calling
+	// NewBloom. Values cannot be removed, use a Cuckoo filter if removal is required. A Bloom filter is not safe for
+	// concurrent use.
+	Bloom[T any] struct {
+		encode func(T) []byte
+		bits   []uint64
+		m      uint64
+		k      uint32
+	}
+)
+
+var (
+	// ErrIncompatible is the error given when combining or decoding filters whose parameters differ.
+	ErrIncompatible = errors.New("incompatible filter")
+	// ErrInvalidEncoding is the error given when decoding a filter from bytes that were not produced by MarshalBinary.
+	ErrInvalidEncoding = errors.New("invalid encoding")
+)
+
+const (
+	bloomEncoding byte = 'b'
+	bloomVersion  byte = 1
+)
+
+// NewBloom returns a new Bloom filter sized to hold the given number of values with the desired false-positive rate,
+// which must be between zero and one exclusive. The encode function converts values into the bytes that are hashed,
+// equal values must produce equal bytes. For example, for strings:
+//
+//	seen := filter.NewBloom(1_000_000, 0.01, func(s string) []byte { return []byte(s) })
+//
+// NewBloom panics if capacity is zero or the rate is out of range.
+func NewBloom[T any](capacity uint, rate float64, encode func(T) []byte) *Bloom[T] {
+	if capacity == 0 {
+		panic("filter: bloom capacity must be greater than zero")
+	}
+
+	if rate <= 0 || rate >= 1 {
+		panic("filter: bloom false-positive rate must be between zero and one")
+	}
+
+	n := float64(capacity)
+	m := uint64(math.Ceil(-n * math.Log(rate) / (math.Ln2 * math.Ln2)))
+	k := uint32(max(1, math.Round(float64(m)/n*math.Ln2)))
+
+	return &Bloom[T]{
+		encode: encode,
+		bits:   make([]uint64, (m+63)/64),
+		m:      m,
+		k:      k,
+	}
+}
+
+// Add a value to the Bloom filter.
+func (b *Bloom[T]) Add(v T) {
+	h1, h2 := hash(b.encode(v))
+	for i := range uint64(b.k) {
+		bit := (h1 + i*h2) % b.m
+		b.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// Contains returns true if the value may have been added to the Bloom filter. It returns false if the value has
+// definitely not been added.
+func (b *Bloom[T]) Contains(v T) bool {
+	h1, h2 := hash(b.encode(v))
+	for i := range uint64(b.k) {
+		bit := (h1 + i*h2) % b.m
+		if b.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Filter returns a Filter that returns true for values that may have been added to the Bloom filter, allowing it to be
+// used with functions such as All.
+func (b *Bloom[T]) Filter() Filter[T] {
+	return b.Contains
+}
+
+// Clear all values from the Bloom filter.
+func (b *Bloom[T]) Clear() {
+	clear(b.bits)
+}
+
+// Union adds all values from the other Bloom filter into this one. Both filters must have been created with the same
+// capacity and false-positive rate, otherwise ErrIncompatible is returned.
+func (b *Bloom[T]) Union(other *Bloom[T]) error {
+	if b.m != other.m || b.k != other.k {
+		return ErrIncompatible
+	}
+
+	for i, word := range other.bits {
+		b.bits[i] |= word
+	}
+
+	return nil
+}
+
+// MarshalBinary encodes the Bloom filter into bytes that can be decoded using UnmarshalBinary.
+func (b *Bloom[T]) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 0, 14+len(b.bits)*8)
+	out = append(out, bloomEncoding, bloomVersion)
+	out = binary.BigEndian.AppendUint64(out, b.m)
+	out = binary.BigEndian.AppendUint32(out, b.k)
+	for _, word := range b.bits {
+		out = binary.BigEndian.AppendUint64(out, word)
+	}
+
+	return out, nil
+}
+
+// UnmarshalBinary decodes the bytes produced by MarshalBinary, replacing the contents of the Bloom filter. The Bloom
+// filter must have been created using NewBloom with an equivalent encode function. Its capacity and false-positive
+// rate are replaced by those of the encoded filter.
+func (b *Bloom[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < 14 || data[0] != bloomEncoding || data[1] != bloomVersion {
+		return ErrInvalidEncoding
+	}
+
+	m := binary.BigEndian.Uint64(data[2:])
+	k := binary.BigEndian.Uint32(data[10:])
+	words := data[14:]
+	if m == 0 || k == 0 || m > uint64(len(words))*8 || uint64(len(words)) != (m+63)/64*8 {
+		return ErrInvalidEncoding
+	}
+
+	bits := make([]uint64, len(words)/8)
+	for i := range bits {
+		bits[i] = binary.BigEndian.Uint64(words[i*8:])
+	}
+
+	b.m, b.k, b.bits = m, k, bits
+	return nil
+}
+
+// hash returns two independent 64-bit hashes of the data. The hashes are stable across processes, so filters can be
+// serialised and shared.
+func hash(data []byte) (uint64, uint64) {
+	h := fnv.New128a()
+	h.Write(data)
+
+	sum := h.Sum(nil)
+	return mix(binary.BigEndian.Uint64(sum[:8])), mix(binary.BigEndian.Uint64(sum[8:])) | 1
+}
+
+// mix improves the distribution of bits within a hash. FNV alone leaves the upper bits largely unchanged for short
+// inputs.
+func mix(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
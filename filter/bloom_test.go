@@ -0,0 +1,100 @@
+package filter_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidsbond/x/filter"
+)
+
+func encodeString(s string) []byte {
+	return []byte(s)
+}
+
+func TestBloom(t *testing.T) {
+	t.Parallel()
+
+	const (
+		capacity = 10000
+		rate     = 0.01
+	)
+
+	t.Run("contains added values", func(t *testing.T) {
+		b := filter.NewBloom(capacity, rate, encodeString)
+		for i := range capacity {
+			b.Add(strconv.Itoa(i))
+		}
+
+		for i := range capacity {
+			require.True(t, b.Contains(strconv.Itoa(i)))
+		}
+	})
+
+	t.Run("false positive rate", func(t *testing.T) {
+		b := filter.NewBloom(capacity, rate, encodeString)
+		for i := range capacity {
+			b.Add(strconv.Itoa(i))
+		}
+
+		var positives int
+		for i := capacity; i < capacity*2; i++ {
+			if b.Contains(strconv.Itoa(i)) {
+				positives++
+			}
+		}
+
+		assert.Less(t, float64(positives)/capacity, rate*2)
+	})
+
+	t.Run("filters slices", func(t *testing.T) {
+		b := filter.NewBloom(capacity, rate, encodeString)
+		b.Add("a")
+		b.Add("c")
+
+		assert.EqualValues(t, []string{"a", "c"}, filter.All([]string{"a", "b", "c"}, b.Filter()))
+
+		b.Clear()
+		assert.False(t, b.Contains("a"))
+	})
+
+	t.Run("union", func(t *testing.T) {
+		a := filter.NewBloom(capacity, rate, encodeString)
+		a.Add("a")
+
+		b := filter.NewBloom(capacity, rate, encodeString)
+		b.Add("b")
+
+		require.NoError(t, a.Union(b))
+		assert.True(t, a.Contains("a"))
+		assert.True(t, a.Contains("b"))
+
+		other := filter.NewBloom(capacity*2, rate, encodeString)
+		assert.ErrorIs(t, a.Union(other), filter.ErrIncompatible)
+	})
+
+	t.Run("serialisation", func(t *testing.T) {
+		b := filter.NewBloom(capacity, rate, encodeString)
+		b.Add("a")
+		b.Add("b")
+
+		data, err := b.MarshalBinary()
+		require.NoError(t, err)
+
+		decoded := filter.NewBloom(1, 0.5, encodeString)
+		require.NoError(t, decoded.UnmarshalBinary(data))
+		assert.True(t, decoded.Contains("a"))
+		assert.True(t, decoded.Contains("b"))
+		require.NoError(t, decoded.Union(b))
+
+		assert.ErrorIs(t, decoded.UnmarshalBinary(data[:len(data)-1]), filter.ErrInvalidEncoding)
+		assert.ErrorIs(t, decoded.UnmarshalBinary([]byte("nonsense")), filter.ErrInvalidEncoding)
+	})
+
+	t.Run("panics on invalid parameters", func(t *testing.T) {
+		assert.Panics(t, func() { filter.NewBloom(0, rate, encodeString) })
+		assert.Panics(t, func() { filter.NewBloom(capacity, 1, encodeString) })
+	})
+}
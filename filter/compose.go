@@ -0,0 +1,98 @@
+package filter
+
+import (
+	"cmp"
+
+	"github.com/davidsbond/x/set"
+)
+
+// And returns a Filter that returns true only if each of the provided filters return true. If no filters are provided,
+// the returned Filter always returns true.
+func And[T any](filters ...Filter[T]) Filter[T] {
+	return func(v T) bool {
+		for _, filter := range filters {
+			if !filter(v) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// Or returns a Filter that returns true if at least one of the provided filters returns true. If no filters are
+// provided, the returned Filter always returns false.
+func Or[T any](filters ...Filter[T]) Filter[T] {
+	return func(v T) bool {
+		for _, filter := range filters {
+			if filter(v) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Not returns a Filter that returns the inverse of the provided filter.
+func Not[T any](filter Filter[T]) Filter[T] {
+	return func(v T) bool {
+		return !filter(v)
+	}
+}
+
+// Xor returns a Filter that returns true if exactly one of the provided filters returns true.
+func Xor[T any](filters ...Filter[T]) Filter[T] {
+	return func(v T) bool {
+		matched := false
+		for _, filter := range filters {
+			if !filter(v) {
+				continue
+			}
+
+			if matched {
+				return false
+			}
+
+			matched = true
+		}
+
+		return matched
+	}
+}
+
+// None returns a Filter that returns true only if none of the provided filters return true. If no filters are
+// provided, the returned Filter always returns true.
+func None[T any](filters ...Filter[T]) Filter[T] {
+	return Not(Or(filters...))
+}
+
+// Equal returns a Filter that returns true if the value is equal to the one provided.
+func Equal[T comparable](value T) Filter[T] {
+	return func(v T) bool {
+		return v == value
+	}
+}
+
+// In returns a Filter that returns true if the value is present within the provided set.Set. The set.Set is not
+// copied, so changes to it are reflected in the results of the Filter.
+func In[T comparable](s *set.Set[T]) Filter[T] {
+	return s.Contains
+}
+
+// Between returns a Filter that returns true if the value is within the inclusive range of lower and upper.
+func Between[T cmp.Ordered](lower, upper T) Filter[T] {
+	return func(v T) bool {
+		return cmp.Compare(v, lower) >= 0 && cmp.Compare(v, upper) <= 0
+	}
+}
+
+// Field returns a Filter that applies the provided filter to a value obtained from the input using the fn function,
+// typically a field of a struct. For example, to filter users by age:
+//
+//	adult := filter.Field(func(u User) int { return u.Age }, filter.Between(18, 150))
+func Field[T, F any](fn func(T) F, filter Filter[F]) Filter[T] {
+	return func(v T) bool {
+		return filter(fn(v))
+	}
+}
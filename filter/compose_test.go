@@ -0,0 +1,123 @@
+package filter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/davidsbond/x/filter"
+	"github.com/davidsbond/x/set"
+)
+
+func TestCompose(t *testing.T) {
+	t.Parallel()
+
+	even := func(v int) bool {
+		return v%2 == 0
+	}
+
+	positive := func(v int) bool {
+		return v > 0
+	}
+
+	allowed := set.New[int]()
+	allowed.Put(2)
+	allowed.Put(3)
+
+	input := []int{-2, -1, 0, 1, 2, 3, 4}
+
+	tt := []struct {
+		Name     string
+		Filter   filter.Filter[int]
+		Expected []int
+	}{
+		{
+			Name:     "and",
+			Filter:   filter.And(even, positive),
+			Expected: []int{2, 4},
+		},
+		{
+			Name:     "and without filters",
+			Filter:   filter.And[int](),
+			Expected: input,
+		},
+		{
+			Name:     "or",
+			Filter:   filter.Or(even, positive),
+			Expected: []int{-2, 0, 1, 2, 3, 4},
+		},
+		{
+			Name:     "or without filters",
+			Filter:   filter.Or[int](),
+			Expected: []int{},
+		},
+		{
+			Name:     "not",
+			Filter:   filter.Not[int](even),
+			Expected: []int{-1, 1, 3},
+		},
+		{
+			Name:     "xor",
+			Filter:   filter.Xor(even, positive),
+			Expected: []int{-2, 0, 1, 3},
+		},
+		{
+			Name:     "xor with more than two filters",
+			Filter:   filter.Xor(even, positive, filter.Equal(3)),
+			Expected: []int{-2, 0, 1},
+		},
+		{
+			Name:     "none",
+			Filter:   filter.None(even, positive),
+			Expected: []int{-1},
+		},
+		{
+			Name:     "equal",
+			Filter:   filter.Equal(1),
+			Expected: []int{1},
+		},
+		{
+			Name:     "in",
+			Filter:   filter.In(allowed),
+			Expected: []int{2, 3},
+		},
+		{
+			Name:     "between",
+			Filter:   filter.Between(-1, 1),
+			Expected: []int{-1, 0, 1},
+		},
+		{
+			Name:     "nested",
+			Filter:   filter.Or(filter.And(even, filter.Not[int](positive)), filter.Equal(3)),
+			Expected: []int{-2, 0, 3},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual := filter.All(input, tc.Filter)
+			assert.EqualValues(t, tc.Expected, actual)
+		})
+	}
+}
+
+func TestField(t *testing.T) {
+	t.Parallel()
+
+	type User struct {
+		Name string
+		Age  int
+	}
+
+	users := []User{
+		{Name: "alice", Age: 17},
+		{Name: "bob", Age: 30},
+		{Name: "carol", Age: 65},
+	}
+
+	adult := filter.Field(func(u User) int { return u.Age }, filter.Between(18, 64))
+	notBob := filter.Field(func(u User) string { return u.Name }, filter.Not(filter.Equal("bob")))
+
+	assert.EqualValues(t, []User{{Name: "bob", Age: 30}}, filter.All(users, adult))
+	assert.EqualValues(t, []User{{Name: "alice", Age: 17}, {Name: "carol", Age: 65}}, filter.All(users, filter.Not(adult), notBob))
+}
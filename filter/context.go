@@ -0,0 +1,127 @@
+package filter
+
+import (
+	"context"
+
+	"github.com/davidsbond/x/convert"
+)
+
+type (
+	// The ContextFilter type is a fallible Filter that may perform I/O, such as permission checks or feature flag
+	// lookups. It returns true or false based on a given input, or an error if the decision could not be made.
+	ContextFilter[T any] func(context.Context, T) (bool, error)
+
+	matcher[T any] func(context.Context, T, []ContextFilter[T]) (bool, error)
+)
+
+// WithContext converts a Filter into a ContextFilter that never returns an error, allowing it to be used alongside
+// other ContextFilter implementations.
+func WithContext[T any](filter Filter[T]) ContextFilter[T] {
+	return func(_ context.Context, v T) (bool, error) {
+		return filter(v), nil
+	}
+}
+
+// AllContext filters the slice of values down to only elements where each provided filter returned true. Filters are
+// evaluated in order and evaluation of an element stops at the first filter that returns false. The first error
+// returned by a filter stops evaluation and is returned, as does cancellation of the provided context. If no filters
+// are provided, the slice is returned unchanged.
+func AllContext[T any](ctx context.Context, values []T, filters ...ContextFilter[T]) ([]T, error) {
+	return evaluate(ctx, values, filters, matchAll)
+}
+
+// AnyContext filters the slice of values down to elements where at least one of the provided filters returned true.
+// Filters are evaluated in order and evaluation of an element stops at the first filter that returns true. The first
+// error returned by a filter stops evaluation and is returned, as does cancellation of the provided context. If no
+// filters are provided, the slice is returned unchanged.
+func AnyContext[T any](ctx context.Context, values []T, filters ...ContextFilter[T]) ([]T, error) {
+	return evaluate(ctx, values, filters, matchAny)
+}
+
+// AllParallel behaves like AllContext but evaluates elements using at most the specified number of goroutines, which
+// is useful when filters are expensive and the slice is large. If workers is less than one, runtime.GOMAXPROCS is
+// used. The order of the output matches the order of the input. The first error returned by a filter cancels the
+// context passed to the remaining filters and is returned.
+func AllParallel[T any](ctx context.Context, values []T, workers int, filters ...ContextFilter[T]) ([]T, error) {
+	return evaluateParallel(ctx, values, workers, filters, matchAll)
+}
+
+// AnyParallel behaves like AnyContext but evaluates elements using at most the specified number of goroutines, which
+// is useful when filters are expensive and the slice is large. If workers is less than one, runtime.GOMAXPROCS is
+// used. The order of the output matches the order of the input. The first error returned by a filter cancels the
+// context passed to the remaining filters and is returned.
+func AnyParallel[T any](ctx context.Context, values []T, workers int, filters ...ContextFilter[T]) ([]T, error) {
+	return evaluateParallel(ctx, values, workers, filters, matchAny)
+}
+
+func evaluate[T any](ctx context.Context, values []T, filters []ContextFilter[T], match matcher[T]) ([]T, error) {
+	if len(filters) == 0 {
+		return values, nil
+	}
+
+	out := make([]T, 0, len(values))
+	for _, value := range values {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		ok, err := match(ctx, value, filters)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			out = append(out, value)
+		}
+	}
+
+	return out, nil
+}
+
+func evaluateParallel[T any](ctx context.Context, values []T, workers int, filters []ContextFilter[T], match matcher[T]) ([]T, error) {
+	if len(filters) == 0 {
+		return values, nil
+	}
+
+	matches, err := convert.SliceParallel(ctx, values, workers, func(ctx context.Context, value T) (bool, error) {
+		return match(ctx, value, filters)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]T, 0, len(values))
+	for i, ok := range matches {
+		if ok {
+			out = append(out, values[i])
+		}
+	}
+
+	return out, nil
+}
+
+func matchAll[T any](ctx context.Context, value T, filters []ContextFilter[T]) (bool, error) {
+	for _, filter := range filters {
+		ok, err := filter(ctx, value)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func matchAny[T any](ctx context.Context, value T, filters []ContextFilter[T]) (bool, error) {
+	for _, filter := range filters {
+		ok, err := filter(ctx, value)
+		if err != nil {
+			return false, err
+		}
+
+		if ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,130 @@
+package filter_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidsbond/x/filter"
+)
+
+func TestAllContext(t *testing.T) {
+	t.Parallel()
+
+	errDenied := errors.New("denied")
+
+	even := filter.WithContext(func(v int) bool {
+		return v%2 == 0
+	})
+
+	failing := func(_ context.Context, v int) (bool, error) {
+		if v == 3 {
+			return false, errDenied
+		}
+
+		return true, nil
+	}
+
+	t.Run("filters elements that match", func(t *testing.T) {
+		actual, err := filter.AllContext(t.Context(), []int{1, 2, 3, 4}, even)
+		require.NoError(t, err)
+		assert.EqualValues(t, []int{2, 4}, actual)
+	})
+
+	t.Run("all elements when no filters", func(t *testing.T) {
+		actual, err := filter.AllContext(t.Context(), []int{1, 2, 3})
+		require.NoError(t, err)
+		assert.EqualValues(t, []int{1, 2, 3}, actual)
+	})
+
+	t.Run("short-circuits filters", func(t *testing.T) {
+		// The failing filter is never called for 3, as it is not even.
+		actual, err := filter.AllContext(t.Context(), []int{1, 2, 3, 4}, even, failing)
+		require.NoError(t, err)
+		assert.EqualValues(t, []int{2, 4}, actual)
+	})
+
+	t.Run("stops on error", func(t *testing.T) {
+		_, err := filter.AllContext(t.Context(), []int{1, 2, 3, 4}, failing)
+		assert.ErrorIs(t, err, errDenied)
+	})
+
+	t.Run("stops on cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		_, err := filter.AllContext(ctx, []int{1, 2, 3}, even)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestAnyContext(t *testing.T) {
+	t.Parallel()
+
+	errDenied := errors.New("denied")
+
+	equal := func(want int) filter.ContextFilter[int] {
+		return filter.WithContext(filter.Equal(want))
+	}
+
+	t.Run("filters elements that match", func(t *testing.T) {
+		actual, err := filter.AnyContext(t.Context(), []int{1, 2, 3, 4}, equal(2), equal(3))
+		require.NoError(t, err)
+		assert.EqualValues(t, []int{2, 3}, actual)
+	})
+
+	t.Run("stops on error", func(t *testing.T) {
+		failing := func(context.Context, int) (bool, error) {
+			return true, errDenied
+		}
+
+		_, err := filter.AnyContext(t.Context(), []int{1, 2}, equal(3), failing)
+		assert.ErrorIs(t, err, errDenied)
+	})
+}
+
+func TestParallel(t *testing.T) {
+	t.Parallel()
+
+	input := make([]int, 1000)
+	for i := range input {
+		input[i] = i
+	}
+
+	t.Run("preserves order", func(t *testing.T) {
+		var calls atomic.Int64
+		divisible := func(n int) filter.ContextFilter[int] {
+			return func(_ context.Context, v int) (bool, error) {
+				calls.Add(1)
+				return v%n == 0, nil
+			}
+		}
+
+		all, err := filter.AllParallel(t.Context(), input, 4, divisible(2), divisible(3))
+		require.NoError(t, err)
+		assert.EqualValues(t, filter.All(input, func(v int) bool { return v%6 == 0 }), all)
+
+		anyOf, err := filter.AnyParallel(t.Context(), input, 0, divisible(500), divisible(333))
+		require.NoError(t, err)
+		assert.EqualValues(t, []int{0, 333, 500, 666, 999}, anyOf)
+		assert.NotZero(t, calls.Load())
+	})
+
+	t.Run("stops on error", func(t *testing.T) {
+		errDenied := errors.New("denied")
+
+		_, err := filter.AllParallel(t.Context(), input, 4, func(_ context.Context, v int) (bool, error) {
+			if v == 500 {
+				return false, errDenied
+			}
+
+			return true, nil
+		})
+
+		assert.ErrorIs(t, err, errDenied)
+	})
+}
@@ -0,0 +1,262 @@
+package filter
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+	"math/rand/v2"
+)
+
+type (
+	// The Cuckoo type is a probabilistic set that, unlike a Bloom filter, supports removing values. Contains never
+	// returns false for a value that has been added and not removed, but may return true for one that has not, at a
+	// rate determined when calling NewCuckoo. Each value is stored as a small fingerprint, so adding the same value
+	// more than once stores it more than once and it must be removed the same number of times. A Cuckoo filter is not
+	// safe for concurrent use.
+	Cuckoo[T any] struct {
+		encode  func(T) []byte
+		slots   []uint32
+		buckets uint64
+		bits    uint32
+		count   uint64
+	}
+
+	// The eviction type records a fingerprint displaced while adding a value, so that it can be restored if the
+	// Cuckoo filter turns out to be full.
+	eviction struct {
+		slot        uint64
+		fingerprint uint32
+	}
+)
+
+var (
+	// ErrFull is the error given when a value cannot be added to a Cuckoo filter because it is at capacity.
+	ErrFull = errors.New("filter is full")
+)
+
+const (
+	cuckooEncoding  byte = 'c'
+	cuckooVersion   byte = 1
+	cuckooSlots          = 4
+	cuckooMaxKicks       = 500
+	cuckooLoadLimit      = 0.95
+)
+
+// NewCuckoo returns a new Cuckoo filter sized to hold at least the given number of values with the desired
+// false-positive rate, which must be between zero and one exclusive. The encode function converts values into the
+// bytes that are hashed, equal values must produce equal bytes. For example, for strings:
+//
+//	seen := filter.NewCuckoo(1_000_000, 0.001, func(s string) []byte { return []byte(s) })
+//
+// NewCuckoo panics if capacity is zero or the rate is out of range.
+func NewCuckoo[T any](capacity uint, rate float64, encode func(T) []byte) *Cuckoo[T] {
+	if capacity == 0 {
+		panic("filter: cuckoo capacity must be greater than zero")
+	}
+
+	if rate <= 0 || rate >= 1 {
+		panic("filter: cuckoo false-positive rate must be between zero and one")
+	}
+
+	// The number of buckets must be a power of two so that the alternate bucket of a fingerprint can be computed
+	// from either bucket.
+	buckets := uint64(math.Ceil(float64(capacity) / (cuckooSlots * cuckooLoadLimit)))
+	buckets = 1 << bits.Len64(max(buckets, 1)-1)
+
+	// Each lookup compares against the fingerprints in two buckets, so the false-positive rate is roughly
+	// 2 * slots / 2^bits.
+	size := uint32(math.Ceil(math.Log2(2 * cuckooSlots / rate)))
+
+	return &Cuckoo[T]{
+		encode:  encode,
+		slots:   make([]uint32, buckets*cuckooSlots),
+		buckets: buckets,
+		bits:    min(max(size, 4), 32),
+	}
+}
+
+// Add a value to the Cuckoo filter. If the Cuckoo filter is full, ErrFull is returned and the Cuckoo filter is left
+// unchanged.
+func (c *Cuckoo[T]) Add(v T) error {
+	i1, fp := c.locate(v)
+	return c.insert(i1, fp)
+}
+
+// Contains returns true if the value may have been added to the Cuckoo filter. It returns false if the value has
+// definitely not been added.
+func (c *Cuckoo[T]) Contains(v T) bool {
+	i1, fp := c.locate(v)
+	return c.find(i1, fp) >= 0 || c.find(c.alternate(i1, fp), fp) >= 0
+}
+
+// Remove a value from the Cuckoo filter, returning true if it was present. Only values that have been added should be
+// removed, removing a value that was not added may remove a different value that shares its fingerprint.
+func (c *Cuckoo[T]) Remove(v T) bool {
+	i1, fp := c.locate(v)
+	for _, i := range []uint64{i1, c.alternate(i1, fp)} {
+		if slot := c.find(i, fp); slot >= 0 {
+			c.slots[slot] = 0
+			c.count--
+			return true
+		}
+	}
+
+	return false
+}
+
+// Len returns the number of values within the Cuckoo filter.
+func (c *Cuckoo[T]) Len() int {
+	return int(c.count)
+}
+
+// Filter returns a Filter that returns true for values that may have been added to the Cuckoo filter, allowing it to
+// be used with functions such as All.
+func (c *Cuckoo[T]) Filter() Filter[T] {
+	return c.Contains
+}
+
+// Clear all values from the Cuckoo filter.
+func (c *Cuckoo[T]) Clear() {
+	clear(c.slots)
+	c.count = 0
+}
+
+// Union adds all values from the other Cuckoo filter into this one. Both filters must have been created with the same
+// capacity and false-positive rate, otherwise ErrIncompatible is returned. If this Cuckoo filter becomes full, ErrFull
+// is returned and the values added so far are kept.
+func (c *Cuckoo[T]) Union(other *Cuckoo[T]) error {
+	if c.buckets != other.buckets || c.bits != other.bits {
+		return ErrIncompatible
+	}
+
+	for slot, fp := range other.slots {
+		if fp == 0 {
+			continue
+		}
+
+		if err := c.insert(uint64(slot/cuckooSlots), fp); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary encodes the Cuckoo filter into bytes that can be decoded using UnmarshalBinary.
+func (c *Cuckoo[T]) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 0, 22+len(c.slots)*4)
+	out = append(out, cuckooEncoding, cuckooVersion)
+	out = binary.BigEndian.AppendUint64(out, c.buckets)
+	out = binary.BigEndian.AppendUint32(out, c.bits)
+	out = binary.BigEndian.AppendUint64(out, c.count)
+	for _, fp := range c.slots {
+		out = binary.BigEndian.AppendUint32(out, fp)
+	}
+
+	return out, nil
+}
+
+// UnmarshalBinary decodes the bytes produced by MarshalBinary, replacing the contents of the Cuckoo filter. The Cuckoo
+// filter must have been created using NewCuckoo with an equivalent encode function. Its capacity and false-positive
+// rate are replaced by those of the encoded filter.
+func (c *Cuckoo[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < 22 || data[0] != cuckooEncoding || data[1] != cuckooVersion {
+		return ErrInvalidEncoding
+	}
+
+	buckets := binary.BigEndian.Uint64(data[2:])
+	size := binary.BigEndian.Uint32(data[10:])
+	count := binary.BigEndian.Uint64(data[14:])
+	fingerprints := data[22:]
+
+	valid := buckets > 0 && bits.OnesCount64(buckets) == 1 && size >= 4 && size <= 32
+	if !valid || buckets > uint64(len(fingerprints)) || uint64(len(fingerprints)) != buckets*cuckooSlots*4 {
+		return ErrInvalidEncoding
+	}
+
+	slots := make([]uint32, buckets*cuckooSlots)
+	for i := range slots {
+		slots[i] = binary.BigEndian.Uint32(fingerprints[i*4:])
+	}
+
+	c.buckets, c.bits, c.count, c.slots = buckets, size, count, slots
+	return nil
+}
+
+// locate returns the primary bucket and the fingerprint of the value. Fingerprints are never zero, as zero denotes an
+// empty slot.
+func (c *Cuckoo[T]) locate(v T) (uint64, uint32) {
+	h1, h2 := hash(c.encode(v))
+
+	fp := uint32(h2 >> (64 - c.bits))
+	if fp == 0 {
+		fp = 1
+	}
+
+	return h1 & (c.buckets - 1), fp
+}
+
+// alternate returns the other bucket a fingerprint may be stored in. Applying it to either bucket returns the other.
+func (c *Cuckoo[T]) alternate(i uint64, fp uint32) uint64 {
+	return (i ^ (uint64(fp) * 0x5bd1e995)) & (c.buckets - 1)
+}
+
+// find returns the slot within the bucket that holds the fingerprint, or -1 if there is none.
+func (c *Cuckoo[T]) find(i uint64, fp uint32) int {
+	for s := i * cuckooSlots; s < (i+1)*cuckooSlots; s++ {
+		if c.slots[s] == fp {
+			return int(s)
+		}
+	}
+
+	return -1
+}
+
+// store places the fingerprint in an empty slot within the bucket, returning false if the bucket is full.
+func (c *Cuckoo[T]) store(i uint64, fp uint32) bool {
+	for s := i * cuckooSlots; s < (i+1)*cuckooSlots; s++ {
+		if c.slots[s] == 0 {
+			c.slots[s] = fp
+			return true
+		}
+	}
+
+	return false
+}
+
+// insert stores the fingerprint in either of its buckets, relocating existing fingerprints to their alternate bucket
+// to make room if required.
+func (c *Cuckoo[T]) insert(i1 uint64, fp uint32) error {
+	i2 := c.alternate(i1, fp)
+	if c.store(i1, fp) || c.store(i2, fp) {
+		c.count++
+		return nil
+	}
+
+	evictions := make([]eviction, 0, cuckooMaxKicks)
+
+	i := i1
+	if rand.IntN(2) == 1 {
+		i = i2
+	}
+
+	for range cuckooMaxKicks {
+		slot := i*cuckooSlots + rand.Uint64N(cuckooSlots)
+		evictions = append(evictions, eviction{slot: slot, fingerprint: c.slots[slot]})
+		fp, c.slots[slot] = c.slots[slot], fp
+
+		i = c.alternate(i, fp)
+		if c.store(i, fp) {
+			c.count++
+			return nil
+		}
+	}
+
+	// The filter is full, so the evictions are undone in reverse to leave every fingerprint in its original slot.
+	for j := len(evictions) - 1; j >= 0; j-- {
+		c.slots[evictions[j].slot] = evictions[j].fingerprint
+	}
+
+	return ErrFull
+}
@@ -0,0 +1,136 @@
+package filter_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidsbond/x/filter"
+)
+
+func TestCuckoo(t *testing.T) {
+	t.Parallel()
+
+	const (
+		capacity = 10000
+		rate     = 0.001
+	)
+
+	t.Run("contains added values", func(t *testing.T) {
+		c := filter.NewCuckoo(capacity, rate, encodeString)
+		for i := range capacity {
+			require.NoError(t, c.Add(strconv.Itoa(i)))
+		}
+
+		assert.EqualValues(t, capacity, c.Len())
+		for i := range capacity {
+			require.True(t, c.Contains(strconv.Itoa(i)))
+		}
+	})
+
+	t.Run("false positive rate", func(t *testing.T) {
+		c := filter.NewCuckoo(capacity, rate, encodeString)
+		for i := range capacity {
+			require.NoError(t, c.Add(strconv.Itoa(i)))
+		}
+
+		var positives int
+		for i := capacity; i < capacity*2; i++ {
+			if c.Contains(strconv.Itoa(i)) {
+				positives++
+			}
+		}
+
+		assert.Less(t, float64(positives)/capacity, rate*2)
+	})
+
+	t.Run("removes values", func(t *testing.T) {
+		c := filter.NewCuckoo(capacity, rate, encodeString)
+		require.NoError(t, c.Add("a"))
+		require.NoError(t, c.Add("b"))
+
+		assert.True(t, c.Remove("a"))
+		assert.False(t, c.Contains("a"))
+		assert.True(t, c.Contains("b"))
+		assert.False(t, c.Remove("a"))
+		assert.EqualValues(t, 1, c.Len())
+	})
+
+	t.Run("returns error when full", func(t *testing.T) {
+		c := filter.NewCuckoo(8, rate, encodeString)
+
+		var (
+			added []string
+			err   error
+		)
+
+		for i := 0; err == nil; i++ {
+			value := strconv.Itoa(i)
+			if err = c.Add(value); err == nil {
+				added = append(added, value)
+			}
+		}
+
+		assert.ErrorIs(t, err, filter.ErrFull)
+		assert.EqualValues(t, len(added), c.Len())
+		for _, value := range added {
+			assert.True(t, c.Contains(value))
+		}
+	})
+
+	t.Run("filters slices", func(t *testing.T) {
+		c := filter.NewCuckoo(capacity, rate, encodeString)
+		require.NoError(t, c.Add("a"))
+		require.NoError(t, c.Add("c"))
+
+		assert.EqualValues(t, []string{"b"}, filter.All([]string{"a", "b", "c"}, filter.Not(c.Filter())))
+
+		c.Clear()
+		assert.Zero(t, c.Len())
+		assert.False(t, c.Contains("a"))
+	})
+
+	t.Run("union", func(t *testing.T) {
+		a := filter.NewCuckoo(capacity, rate, encodeString)
+		require.NoError(t, a.Add("a"))
+
+		b := filter.NewCuckoo(capacity, rate, encodeString)
+		require.NoError(t, b.Add("b"))
+
+		require.NoError(t, a.Union(b))
+		assert.True(t, a.Contains("a"))
+		assert.True(t, a.Contains("b"))
+		assert.EqualValues(t, 2, a.Len())
+
+		other := filter.NewCuckoo(capacity*4, rate, encodeString)
+		assert.ErrorIs(t, a.Union(other), filter.ErrIncompatible)
+	})
+
+	t.Run("serialisation", func(t *testing.T) {
+		c := filter.NewCuckoo(capacity, rate, encodeString)
+		require.NoError(t, c.Add("a"))
+		require.NoError(t, c.Add("b"))
+
+		data, err := c.MarshalBinary()
+		require.NoError(t, err)
+
+		decoded := filter.NewCuckoo(1, 0.5, encodeString)
+		require.NoError(t, decoded.UnmarshalBinary(data))
+		assert.True(t, decoded.Contains("a"))
+		assert.True(t, decoded.Contains("b"))
+		assert.EqualValues(t, 2, decoded.Len())
+
+		assert.ErrorIs(t, decoded.UnmarshalBinary(data[:len(data)-1]), filter.ErrInvalidEncoding)
+
+		bloom, err := filter.NewBloom(capacity, rate, encodeString).MarshalBinary()
+		require.NoError(t, err)
+		assert.ErrorIs(t, decoded.UnmarshalBinary(bloom), filter.ErrInvalidEncoding)
+	})
+
+	t.Run("panics on invalid parameters", func(t *testing.T) {
+		assert.Panics(t, func() { filter.NewCuckoo(0, rate, encodeString) })
+		assert.Panics(t, func() { filter.NewCuckoo(capacity, 0, encodeString) })
+	})
+}
@@ -16,15 +16,7 @@ func All[T any](values []T, filters ...Filter[T]) []T {
 	out := make([]T, 0, len(values))
 
 	for _, value := range values {
-		ok := true
-		for _, filter := range filters {
-			if !filter(value) {
-				ok = false
-				break
-			}
-		}
-
-		if ok {
+		if match(value, filters) {
 			out = append(out, value)
 		}
 	}
@@ -52,3 +44,83 @@ func Any[T any](values []T, filters ...Filter[T]) []T {
 
 	return out
 }
+
+// InPlace filters the slice of values down to only elements where each provided filter returned true, like All.
+// Rather than allocating a new slice, matching elements are moved to the front of the input's backing array and the
+// remaining elements are zeroed so that they can be garbage collected. The input slice must not be used after calling
+// InPlace, only the returned one.
+func InPlace[T any](values []T, filters ...Filter[T]) []T {
+	if len(filters) == 0 {
+		return values
+	}
+
+	n := 0
+	for _, value := range values {
+		if match(value, filters) {
+			values[n] = value
+			n++
+		}
+	}
+
+	clear(values[n:])
+	return values[:n]
+}
+
+// Partition splits the slice of values into those where each provided filter returned true and those where at least
+// one did not, in a single pass. The relative order of elements is preserved within both slices. If no filters are
+// provided, all elements are considered matching.
+func Partition[T any](values []T, filters ...Filter[T]) (matched []T, unmatched []T) {
+	matched = make([]T, 0, len(values))
+	unmatched = make([]T, 0)
+
+	for _, value := range values {
+		if match(value, filters) {
+			matched = append(matched, value)
+		} else {
+			unmatched = append(unmatched, value)
+		}
+	}
+
+	return matched, unmatched
+}
+
+// Count returns the number of elements in the slice of values where each provided filter returned true.
+func Count[T any](values []T, filters ...Filter[T]) int {
+	var count int
+	for _, value := range values {
+		if match(value, filters) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// First returns the first element in the slice of values where each provided filter returned true. The boolean return
+// value is false if no element matched.
+func First[T any](values []T, filters ...Filter[T]) (T, bool) {
+	for _, value := range values {
+		if match(value, filters) {
+			return value, true
+		}
+	}
+
+	var zero T
+	return zero, false
+}
+
+// Exists returns true if at least one element in the slice of values is one where each provided filter returned true.
+func Exists[T any](values []T, filters ...Filter[T]) bool {
+	_, ok := First(values, filters...)
+	return ok
+}
+
+func match[T any](value T, filters []Filter[T]) bool {
+	for _, filter := range filters {
+		if !filter(value) {
+			return false
+		}
+	}
+
+	return true
+}
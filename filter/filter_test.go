@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/davidsbond/x/filter"
 )
@@ -78,3 +79,73 @@ func TestAny(t *testing.T) {
 		})
 	}
 }
+
+func TestInPlace(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reuses backing array", func(t *testing.T) {
+		input := []*int{new(1), new(2), new(3), new(4)}
+		actual := filter.InPlace(input, func(v *int) bool {
+			return *v%2 == 0
+		})
+
+		require.Len(t, actual, 2)
+		assert.EqualValues(t, 2, *actual[0])
+		assert.EqualValues(t, 4, *actual[1])
+		assert.Same(t, &input[0], &actual[0])
+		assert.Nil(t, input[2])
+		assert.Nil(t, input[3])
+	})
+
+	t.Run("returns input when no filters", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		assert.EqualValues(t, input, filter.InPlace(input))
+	})
+}
+
+func TestPartition(t *testing.T) {
+	t.Parallel()
+
+	matched, unmatched := filter.Partition([]int{1, 2, 3, 4, 5}, func(v int) bool {
+		return v%2 == 0
+	})
+
+	assert.EqualValues(t, []int{2, 4}, matched)
+	assert.EqualValues(t, []int{1, 3, 5}, unmatched)
+}
+
+func TestCount(t *testing.T) {
+	t.Parallel()
+
+	input := []int{1, 2, 3, 4, 5}
+
+	assert.EqualValues(t, 5, filter.Count(input))
+	assert.EqualValues(t, 2, filter.Count(input, filter.Between(2, 3)))
+	assert.EqualValues(t, 0, filter.Count(input, filter.Equal(6)))
+}
+
+func TestFirst(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	counted := func(v int) bool {
+		calls++
+		return v > 2
+	}
+
+	actual, ok := filter.First([]int{1, 2, 3, 4, 5}, counted)
+	require.True(t, ok)
+	assert.EqualValues(t, 3, actual)
+	assert.EqualValues(t, 3, calls)
+
+	_, ok = filter.First([]int{1, 2}, counted)
+	assert.False(t, ok)
+}
+
+func TestExists(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, filter.Exists([]string{"a", "b"}, filter.Equal("b")))
+	assert.False(t, filter.Exists([]string{"a", "b"}, filter.Equal("c")))
+	assert.False(t, filter.Exists([]string{}))
+}
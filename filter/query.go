@@ -0,0 +1,584 @@
+package filter
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type (
+	// The Fields type is a registry of named accessors used to resolve field names within a query to values of T. See
+	// Compile for details.
+	Fields[T any] map[string]func(T) any
+
+	// The ParseError type is returned by Compile when a query is invalid.
+	ParseError struct {
+		// The 1-based position of the offending character within the query.
+		Position int
+		// A description of the problem.
+		Message string
+	}
+
+	tokenKind uint
+
+	token struct {
+		kind  tokenKind
+		text  string
+		value literal
+		pos   int
+	}
+
+	literalKind uint
+
+	literal struct {
+		kind   literalKind
+		str    string
+		number float64
+		bool   bool
+		// Integer literals are also kept exactly, as float64 cannot represent every integer above 2^53. The int and
+		// uint fields are only set when the literal is within their range.
+		int     int64
+		uint    uint64
+		hasInt  bool
+		hasUint bool
+	}
+
+	queryParser[T any] struct {
+		fields Fields[T]
+		tokens []token
+		pos    int
+	}
+)
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenLiteral
+	tokenOperator
+	tokenLParen
+	tokenRParen
+)
+
+const (
+	literalString literalKind = iota
+	literalNumber
+	literalBool
+)
+
+// FieldsOf returns Fields for the struct type T, or a pointer to one, using the "filter" struct tag to name each field.
+// Fields without the tag, or tagged with "-", cannot be queried. For example:
+//
+//	type User struct {
+//		Status string   `filter:"status"`
+//		Age    int      `filter:"age"`
+//		Tags   []string `filter:"tags"`
+//	}
+//
+// FieldsOf panics if T is not a struct or pointer to a struct.
+func FieldsOf[T any]() Fields[T] {
+	rt := reflect.TypeFor[T]()
+	ptr := rt.Kind() == reflect.Pointer
+	if ptr {
+		rt = rt.Elem()
+	}
+
+	if rt.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("filter: FieldsOf requires a struct type, got %s", rt))
+	}
+
+	fields := make(Fields[T])
+	for _, field := range reflect.VisibleFields(rt) {
+		name, ok := field.Tag.Lookup("filter")
+		if !ok || name == "-" || !field.IsExported() {
+			continue
+		}
+
+		index := field.Index
+		fields[name] = func(v T) any {
+			rv := reflect.ValueOf(v)
+			if ptr {
+				if rv.IsNil() {
+					return nil
+				}
+
+				rv = rv.Elem()
+			}
+
+			fv, err := rv.FieldByIndexErr(index)
+			if err != nil {
+				// A nil embedded pointer along the path to the field.
+				return nil
+			}
+
+			return fv.Interface()
+		}
+	}
+
+	return fields
+}
+
+// Compile parses the query and returns a Filter that returns true for values of T that match it. Field names within
+// the query are resolved using the provided Fields, which can be built by hand or via FieldsOf. Queries are made up of
+// comparisons between a field and a literal value, combined using AND, OR and NOT and grouped using parentheses:
+//
+//	status = "active" AND (age >= 18 OR tags contains "vip")
+//
+// AND binds more tightly than OR, so "a = 1 OR b = 2 AND c = 3" is equivalent to "a = 1 OR (b = 2 AND c = 3)".
+// Keywords are case-insensitive. Literals may be double-quoted strings, numbers or the booleans true and false. The
+// supported operators are:
+//
+//   - = and !=, which compare strings, numbers and booleans.
+//   - <, <=, > and >=, which compare strings and numbers.
+//   - contains, which checks for a substring within a string, an element within a slice or array, or a key within
+//     a map.
+//
+// A comparison against a field whose value is nil or of an incompatible type does not match. Any error within the
+// query is returned as a *ParseError describing its position.
+func Compile[T any](query string, fields Fields[T]) (Filter[T], error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &queryParser[T]{
+		fields: fields,
+		tokens: tokens,
+	}
+
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.errorf(tok, "unexpected %s", tok)
+	}
+
+	return filter, nil
+}
+
+// MustCompile is like Compile but panics if the query is invalid. It is intended for queries that are known at
+// compile time.
+func MustCompile[T any](query string, fields Fields[T]) Filter[T] {
+	filter, err := Compile(query, fields)
+	if err != nil {
+		panic(err)
+	}
+
+	return filter
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("filter: position %d: %s", e.Position, e.Message)
+}
+
+func (p *queryParser[T]) parseOr() (Filter[T], error) {
+	filters, err := p.parseList("or", p.parseAnd)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(filters) == 1 {
+		return filters[0], nil
+	}
+
+	return Or(filters...), nil
+}
+
+func (p *queryParser[T]) parseAnd() (Filter[T], error) {
+	filters, err := p.parseList("and", p.parseUnary)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(filters) == 1 {
+		return filters[0], nil
+	}
+
+	return And(filters...), nil
+}
+
+// parseList parses one or more operands separated by the given keyword.
+func (p *queryParser[T]) parseList(keyword string, operand func() (Filter[T], error)) ([]Filter[T], error) {
+	var filters []Filter[T]
+	for {
+		filter, err := operand()
+		if err != nil {
+			return nil, err
+		}
+
+		filters = append(filters, filter)
+		if !p.keyword(keyword) {
+			return filters, nil
+		}
+
+		p.pos++
+	}
+}
+
+func (p *queryParser[T]) parseUnary() (Filter[T], error) {
+	if p.keyword("not") {
+		p.pos++
+
+		filter, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return Not(filter), nil
+	}
+
+	tok := p.next()
+	switch tok.kind {
+	case tokenLParen:
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if end := p.next(); end.kind != tokenRParen {
+			return nil, p.errorf(end, "expected ')' but found %s", end)
+		}
+
+		return filter, nil
+	case tokenIdent:
+		return p.parseComparison(tok)
+	default:
+		return nil, p.errorf(tok, "expected field name but found %s", tok)
+	}
+}
+
+func (p *queryParser[T]) parseComparison(field token) (Filter[T], error) {
+	accessor, ok := p.fields[field.text]
+	if !ok {
+		return nil, p.errorf(field, "unknown field %q", field.text)
+	}
+
+	op := p.next()
+	switch {
+	case op.kind == tokenOperator:
+	case op.kind == tokenIdent && strings.EqualFold(op.text, "contains"):
+		op.text = "contains"
+	default:
+		return nil, p.errorf(op, "expected operator but found %s", op)
+	}
+
+	value := p.next()
+	if value.kind != tokenLiteral {
+		return nil, p.errorf(value, "expected value but found %s", value)
+	}
+
+	lit := value.value
+	switch op.text {
+	case "<", "<=", ">", ">=":
+		if lit.kind == literalBool {
+			return nil, p.errorf(op, "operator %s cannot be used with a boolean", op.text)
+		}
+	}
+
+	compare := comparison(op.text, lit)
+	return func(v T) bool {
+		return compare(reflect.ValueOf(accessor(v)))
+	}, nil
+}
+
+func (p *queryParser[T]) keyword(keyword string) bool {
+	tok := p.peek()
+	return tok.kind == tokenIdent && strings.EqualFold(tok.text, keyword)
+}
+
+func (p *queryParser[T]) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *queryParser[T]) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+
+	return tok
+}
+
+func (p *queryParser[T]) errorf(tok token, format string, args ...any) error {
+	return &ParseError{Position: tok.pos + 1, Message: fmt.Sprintf(format, args...)}
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of query"
+	case tokenLiteral:
+		return "value " + t.text
+	default:
+		return strconv.Quote(t.text)
+	}
+}
+
+func tokenize(query string) ([]token, error) {
+	var tokens []token
+
+	for pos := 0; pos < len(query); {
+		c := query[pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			pos++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: pos})
+			pos++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: pos})
+			pos++
+		case c == '=':
+			tokens = append(tokens, token{kind: tokenOperator, text: "=", pos: pos})
+			pos++
+		case c == '!' || c == '<' || c == '>':
+			text := query[pos : pos+1]
+			if pos+1 < len(query) && query[pos+1] == '=' {
+				text = query[pos : pos+2]
+			}
+
+			if text == "!" {
+				return nil, &ParseError{Position: pos + 1, Message: "expected '=' after '!'"}
+			}
+
+			tokens = append(tokens, token{kind: tokenOperator, text: text, pos: pos})
+			pos += len(text)
+		case c == '"':
+			tok, err := scanString(query, pos)
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, tok)
+			pos += len(tok.text)
+		case c == '-' || isDigit(c):
+			tok, err := scanNumber(query, pos)
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, tok)
+			pos += len(tok.text)
+		case isIdentStart(c):
+			end := pos
+			for end < len(query) && isIdentPart(query[end]) {
+				end++
+			}
+
+			text := query[pos:end]
+			switch {
+			case strings.EqualFold(text, "true"), strings.EqualFold(text, "false"):
+				lit := literal{kind: literalBool, bool: strings.EqualFold(text, "true")}
+				tokens = append(tokens, token{kind: tokenLiteral, text: text, value: lit, pos: pos})
+			default:
+				tokens = append(tokens, token{kind: tokenIdent, text: text, pos: pos})
+			}
+
+			pos = end
+		default:
+			return nil, &ParseError{Position: pos + 1, Message: fmt.Sprintf("unexpected character %q", c)}
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(query)}), nil
+}
+
+func scanString(query string, start int) (token, error) {
+	for end := start + 1; end < len(query); end++ {
+		switch query[end] {
+		case '\\':
+			end++
+		case '"':
+			text := query[start : end+1]
+			str, err := strconv.Unquote(text)
+			if err != nil {
+				return token{}, &ParseError{Position: start + 1, Message: fmt.Sprintf("invalid string %s", text)}
+			}
+
+			lit := literal{kind: literalString, str: str}
+			return token{kind: tokenLiteral, text: text, value: lit, pos: start}, nil
+		}
+	}
+
+	return token{}, &ParseError{Position: start + 1, Message: "unterminated string"}
+}
+
+func scanNumber(query string, start int) (token, error) {
+	end := start + 1
+	for end < len(query) && (isDigit(query[end]) || query[end] == '.') {
+		end++
+	}
+
+	text := query[start:end]
+	number, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return token{}, &ParseError{Position: start + 1, Message: fmt.Sprintf("invalid number %q", text)}
+	}
+
+	lit := literal{kind: literalNumber, number: number}
+	if !strings.Contains(text, ".") {
+		if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+			lit.int, lit.hasInt = i, true
+		}
+
+		if u, err := strconv.ParseUint(text, 10, 64); err == nil {
+			lit.uint, lit.hasUint = u, true
+		}
+	}
+
+	return token{kind: tokenLiteral, text: text, value: lit, pos: start}, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return c == '.' || isIdentStart(c) || isDigit(c)
+}
+
+// comparison returns a function that compares a field value against the literal using the given operator.
+func comparison(op string, lit literal) func(reflect.Value) bool {
+	if op == "contains" {
+		return func(rv reflect.Value) bool {
+			return contains(rv, lit)
+		}
+	}
+
+	return func(rv reflect.Value) bool {
+		result, ok := compare(rv, lit)
+		if !ok {
+			return false
+		}
+
+		switch op {
+		case "=":
+			return result == 0
+		case "!=":
+			return result != 0
+		case "<":
+			return result < 0
+		case "<=":
+			return result <= 0
+		case ">":
+			return result > 0
+		case ">=":
+			return result >= 0
+		default:
+			return false
+		}
+	}
+}
+
+// compare compares the value with the literal, returning -1, 0 or +1 as per cmp.Compare. The boolean return value is
+// false if the value cannot be compared with the literal.
+func compare(rv reflect.Value, lit literal) (int, bool) {
+	rv, ok := indirect(rv)
+	if !ok {
+		return 0, false
+	}
+
+	switch lit.kind {
+	case literalString:
+		if rv.Kind() == reflect.String {
+			return cmp.Compare(rv.String(), lit.str), true
+		}
+
+		if s, ok := rv.Interface().(fmt.Stringer); ok {
+			return cmp.Compare(s.String(), lit.str), true
+		}
+	case literalNumber:
+		switch {
+		case rv.CanInt():
+			return compareInt(rv.Int(), lit), true
+		case rv.CanUint():
+			return compareUint(rv.Uint(), lit), true
+		case rv.CanFloat():
+			return cmp.Compare(rv.Float(), lit.number), true
+		}
+	case literalBool:
+		if rv.Kind() != reflect.Bool {
+			return 0, false
+		}
+
+		if rv.Bool() == lit.bool {
+			return 0, true
+		}
+
+		return 1, true
+	}
+
+	return 0, false
+}
+
+// compareInt compares a signed integer with the literal, exactly if the literal is an integer.
+func compareInt(v int64, lit literal) int {
+	switch {
+	case lit.hasInt:
+		return cmp.Compare(v, lit.int)
+	case lit.hasUint:
+		// The literal is greater than math.MaxInt64.
+		return -1
+	default:
+		return cmp.Compare(float64(v), lit.number)
+	}
+}
+
+// compareUint compares an unsigned integer with the literal, exactly if the literal is an integer.
+func compareUint(v uint64, lit literal) int {
+	switch {
+	case lit.hasUint:
+		return cmp.Compare(v, lit.uint)
+	case lit.hasInt:
+		// The literal is negative.
+		return 1
+	default:
+		return cmp.Compare(float64(v), lit.number)
+	}
+}
+
+func contains(rv reflect.Value, lit literal) bool {
+	rv, ok := indirect(rv)
+	if !ok {
+		return false
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		return lit.kind == literalString && strings.Contains(rv.String(), lit.str)
+	case reflect.Slice, reflect.Array:
+		for i := range rv.Len() {
+			if result, ok := compare(rv.Index(i), lit); ok && result == 0 {
+				return true
+			}
+		}
+	case reflect.Map:
+		for _, key := range rv.MapKeys() {
+			if result, ok := compare(key, lit); ok && result == 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// indirect dereferences pointers and interfaces, returning false if the value is nil.
+func indirect(rv reflect.Value) (reflect.Value, bool) {
+	for rv.IsValid() && (rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface) {
+		if rv.IsNil() {
+			return rv, false
+		}
+
+		rv = rv.Elem()
+	}
+
+	return rv, rv.IsValid()
+}
@@ -0,0 +1,310 @@
+package filter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidsbond/x/filter"
+)
+
+type (
+	Status string
+
+	Account struct {
+		Name    string         `filter:"name"`
+		Status  Status         `filter:"status"`
+		Age     int            `filter:"age"`
+		Score   float64        `filter:"score"`
+		Active  bool           `filter:"active"`
+		Tags    []string       `filter:"tags"`
+		Labels  map[string]int `filter:"labels"`
+		Manager *Account       `filter:"manager"`
+		Secret  string         `filter:"-"`
+		Ignored string
+	}
+)
+
+func TestCompile(t *testing.T) {
+	t.Parallel()
+
+	accounts := []Account{
+		{Name: "alice", Status: "active", Age: 34, Score: 9.5, Active: true, Tags: []string{"vip"}},
+		{Name: "bob", Status: "inactive", Age: 17, Score: 4, Tags: []string{"new"}, Labels: map[string]int{"trial": 1}},
+		{Name: "carol", Status: "active", Age: 16, Score: 7.25, Active: true, Tags: []string{"vip", "new"}},
+		{Name: "dave", Status: "banned", Age: 52, Score: 0, Manager: &Account{Name: "alice"}},
+	}
+
+	names := func(accounts []Account) []string {
+		out := make([]string, len(accounts))
+		for i, account := range accounts {
+			out[i] = account.Name
+		}
+
+		return out
+	}
+
+	tt := []struct {
+		Name     string
+		Query    string
+		Expected []string
+	}{
+		{
+			Name:     "equal",
+			Query:    `status = "active"`,
+			Expected: []string{"alice", "carol"},
+		},
+		{
+			Name:     "not equal",
+			Query:    `status != "active"`,
+			Expected: []string{"bob", "dave"},
+		},
+		{
+			Name:     "ordered numbers",
+			Query:    `age >= 18`,
+			Expected: []string{"alice", "dave"},
+		},
+		{
+			Name:     "ordered floats",
+			Query:    `score > 4 AND score <= 9.5`,
+			Expected: []string{"alice", "carol"},
+		},
+		{
+			Name:     "ordered strings",
+			Query:    `name < "c"`,
+			Expected: []string{"alice", "bob"},
+		},
+		{
+			Name:     "booleans",
+			Query:    `active = true`,
+			Expected: []string{"alice", "carol"},
+		},
+		{
+			Name:     "and binds more tightly than or",
+			Query:    `status = "active" AND age >= 18 OR tags contains "vip"`,
+			Expected: []string{"alice", "carol"},
+		},
+		{
+			Name:     "parentheses",
+			Query:    `status = "active" AND (age >= 18 OR tags contains "new")`,
+			Expected: []string{"alice", "carol"},
+		},
+		{
+			Name:     "not",
+			Query:    `NOT (status = "active" OR age < 18)`,
+			Expected: []string{"dave"},
+		},
+		{
+			Name:     "case-insensitive keywords",
+			Query:    `status = "active" and not tags CONTAINS "new"`,
+			Expected: []string{"alice"},
+		},
+		{
+			Name:     "contains substring",
+			Query:    `name contains "o"`,
+			Expected: []string{"bob", "carol"},
+		},
+		{
+			Name:     "contains map key",
+			Query:    `labels contains "trial"`,
+			Expected: []string{"bob"},
+		},
+		{
+			Name:     "nil pointer does not match",
+			Query:    `manager != "nobody"`,
+			Expected: []string{},
+		},
+		{
+			Name:     "incompatible type does not match",
+			Query:    `age = "34"`,
+			Expected: []string{},
+		},
+		{
+			Name:     "escaped strings",
+			Query:    `name != "\"quoted\""`,
+			Expected: []string{"alice", "bob", "carol", "dave"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			fn, err := filter.Compile(tc.Query, filter.FieldsOf[Account]())
+			require.NoError(t, err)
+
+			assert.EqualValues(t, tc.Expected, names(filter.All(accounts, fn)))
+		})
+	}
+}
+
+func TestCompile_Fields(t *testing.T) {
+	t.Parallel()
+
+	fields := filter.Fields[*Account]{
+		"manager.name": func(a *Account) any {
+			if a.Manager == nil {
+				return nil
+			}
+
+			return a.Manager.Name
+		},
+	}
+
+	fn, err := filter.Compile(`manager.name = "alice"`, fields)
+	require.NoError(t, err)
+
+	assert.True(t, fn(&Account{Manager: &Account{Name: "alice"}}))
+	assert.False(t, fn(&Account{}))
+}
+
+func TestCompile_Integers(t *testing.T) {
+	t.Parallel()
+
+	type Record struct {
+		Signed   int64   `filter:"signed"`
+		Unsigned uint64  `filter:"unsigned"`
+		Float    float64 `filter:"float"`
+	}
+
+	record := Record{Signed: 9007199254740992, Unsigned: 18446744073709551615, Float: 0.5}
+
+	tt := []struct {
+		Name     string
+		Query    string
+		Expected bool
+	}{
+		{
+			Name:     "signed above 2^53",
+			Query:    `signed = 9007199254740993`,
+			Expected: false,
+		},
+		{
+			Name:     "signed ordered above 2^53",
+			Query:    `signed < 9007199254740993`,
+			Expected: true,
+		},
+		{
+			Name:     "signed against literal above int64",
+			Query:    `signed < 9223372036854775808`,
+			Expected: true,
+		},
+		{
+			Name:     "signed against float literal",
+			Query:    `signed > 0.5`,
+			Expected: true,
+		},
+		{
+			Name:     "unsigned maximum",
+			Query:    `unsigned = 18446744073709551615`,
+			Expected: true,
+		},
+		{
+			Name:     "unsigned below maximum",
+			Query:    `unsigned = 18446744073709551614`,
+			Expected: false,
+		},
+		{
+			Name:     "unsigned against negative literal",
+			Query:    `unsigned > -1`,
+			Expected: true,
+		},
+		{
+			Name:     "float against integer literal",
+			Query:    `float < 1`,
+			Expected: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			fn, err := filter.Compile(tc.Query, filter.FieldsOf[Record]())
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.Expected, fn(record))
+		})
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Name     string
+		Query    string
+		Expected string
+	}{
+		{
+			Name:     "empty query",
+			Query:    ``,
+			Expected: `filter: position 1: expected field name but found end of query`,
+		},
+		{
+			Name:     "unknown field",
+			Query:    `status = "active" AND secret = "x"`,
+			Expected: `filter: position 23: unknown field "secret"`,
+		},
+		{
+			Name:     "missing operator",
+			Query:    `age 18`,
+			Expected: `filter: position 5: expected operator but found value 18`,
+		},
+		{
+			Name:     "missing value",
+			Query:    `age >=`,
+			Expected: `filter: position 7: expected value but found end of query`,
+		},
+		{
+			Name:     "unterminated string",
+			Query:    `name = "alice`,
+			Expected: `filter: position 8: unterminated string`,
+		},
+		{
+			Name:     "unbalanced parentheses",
+			Query:    `(age > 1 OR age < 0`,
+			Expected: `filter: position 20: expected ')' but found end of query`,
+		},
+		{
+			Name:     "trailing input",
+			Query:    `age > 1 age < 0`,
+			Expected: `filter: position 9: unexpected "age"`,
+		},
+		{
+			Name:     "unexpected character",
+			Query:    `age > 1 && age < 0`,
+			Expected: `filter: position 9: unexpected character '&'`,
+		},
+		{
+			Name:     "ordered boolean",
+			Query:    `active > true`,
+			Expected: `filter: position 8: operator > cannot be used with a boolean`,
+		},
+		{
+			Name:     "invalid number",
+			Query:    `score = 1.2.3`,
+			Expected: `filter: position 9: invalid number "1.2.3"`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := filter.Compile(tc.Query, filter.FieldsOf[Account]())
+			require.Error(t, err)
+
+			var target *filter.ParseError
+			require.ErrorAs(t, err, &target)
+			assert.EqualValues(t, tc.Expected, err.Error())
+		})
+	}
+}
+
+func TestMustCompile(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() {
+		filter.MustCompile(`age >`, filter.FieldsOf[Account]())
+	})
+
+	assert.Panics(t, func() {
+		filter.FieldsOf[int]()
+	})
+}
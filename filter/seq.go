@@ -0,0 +1,54 @@
+package filter
+
+import (
+	"iter"
+)
+
+// Seq returns an iter.Seq that lazily yields only the values of the given iter.Seq where each provided filter returned
+// true. Filters are evaluated as the returned iter.Seq is consumed, so it can be used with unbounded sequences and
+// sequences such as set.Set.Range.
+func Seq[T any](seq iter.Seq[T], filters ...Filter[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for value := range seq {
+			if !match(value, filters) {
+				continue
+			}
+
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Seq2 returns an iter.Seq2 that lazily yields only the pairs of the given iter.Seq2 where the provided function
+// returned true. It can be used with sequences such as syncmap.Map.Range.
+func Seq2[K, V any](seq iter.Seq2[K, V], fn func(K, V) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range seq {
+			if !fn(k, v) {
+				continue
+			}
+
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns an iter.Seq2 that lazily yields only the pairs of the given iter.Seq2 whose keys are ones where each
+// provided filter returned true.
+func Keys[K, V any](seq iter.Seq2[K, V], filters ...Filter[K]) iter.Seq2[K, V] {
+	return Seq2(seq, func(k K, _ V) bool {
+		return match(k, filters)
+	})
+}
+
+// Values returns an iter.Seq2 that lazily yields only the pairs of the given iter.Seq2 whose values are ones where
+// each provided filter returned true.
+func Values[K, V any](seq iter.Seq2[K, V], filters ...Filter[V]) iter.Seq2[K, V] {
+	return Seq2(seq, func(_ K, v V) bool {
+		return match(v, filters)
+	})
+}
@@ -0,0 +1,70 @@
+package filter_test
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/davidsbond/x/filter"
+	"github.com/davidsbond/x/set"
+	"github.com/davidsbond/x/syncmap"
+)
+
+func TestSeq(t *testing.T) {
+	t.Parallel()
+
+	t.Run("filters values", func(t *testing.T) {
+		s := set.New[int]()
+		for i := range 10 {
+			s.Put(i)
+		}
+
+		actual := slices.Sorted(filter.Seq(s.Range(), filter.Between(3, 5)))
+		assert.EqualValues(t, []int{3, 4, 5}, actual)
+	})
+
+	t.Run("evaluates lazily", func(t *testing.T) {
+		var calls int
+		counted := func(v int) bool {
+			calls++
+			return v%2 == 0
+		}
+
+		for v := range filter.Seq(slices.Values([]int{1, 2, 3, 4, 5, 6}), counted) {
+			if v == 4 {
+				break
+			}
+		}
+
+		assert.EqualValues(t, 4, calls)
+	})
+}
+
+func TestSeq2(t *testing.T) {
+	t.Parallel()
+
+	m := syncmap.New[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+
+	t.Run("filters pairs", func(t *testing.T) {
+		actual := maps.Collect(filter.Seq2(m.Range(), func(k string, v int) bool {
+			return k != "a" && v < 3
+		}))
+
+		assert.EqualValues(t, map[string]int{"b": 2}, actual)
+	})
+
+	t.Run("filters keys", func(t *testing.T) {
+		actual := maps.Collect(filter.Keys(m.Range(), filter.Not(filter.Equal("b"))))
+		assert.EqualValues(t, map[string]int{"a": 1, "c": 3}, actual)
+	})
+
+	t.Run("filters values", func(t *testing.T) {
+		actual := maps.Collect(filter.Values(m.Range(), filter.Between(2, 3)))
+		assert.EqualValues(t, map[string]int{"b": 2, "c": 3}, actual)
+	})
+}
@@ -0,0 +1,106 @@
+package future
+
+import (
+	"context"
+	"errors"
+)
+
+type (
+	// The Pair type contains the values of two futures combined using Join2.
+	Pair[A, B any] struct {
+		First  A
+		Second B
+	}
+
+	// The Triple type contains the values of three futures combined using Join3.
+	Triple[A, B, C any] struct {
+		First  A
+		Second B
+		Third  C
+	}
+)
+
+// Then returns a Future whose result is that of calling fn with the value of the given Future once it has completed.
+// If the given Future fails, fn is not called and the returned Future fails with the same error. The function is given
+// a context derived from the one the given Future was created with. The returned Future inherits the RePanic option
+// from the given Future.
+func Then[A, B any](f *Future[A], fn func(context.Context, A) (B, error)) *Future[B] {
+	return Do(f.parent, func(ctx context.Context) (B, error) {
+		value, err := f.wait(ctx)
+		if err != nil {
+			var v B
+			return v, err
+		}
+
+		return fn(ctx, value)
+	}, inherit(f.repanic)...)
+}
+
+// Map returns a Future whose result is that of calling fn with the value of the given Future once it has completed.
+// If the given Future fails, fn is not called and the returned Future fails with the same error.
+func Map[A, B any](f *Future[A], fn func(A) B) *Future[B] {
+	return Then(f, func(_ context.Context, value A) (B, error) {
+		return fn(value), nil
+	})
+}
+
+// Recover returns a Future whose result is that of the given Future if it succeeds. If it fails, fn is called with
+// the error and the returned Future's result is that of fn, allowing a fallback value to be provided or the error to
+// be replaced. The function is given a context derived from the one the given Future was created with. If the given
+// Future was created with the RePanic option, a panic is not passed to fn and the returned Future panics instead.
+func Recover[T any](f *Future[T], fn func(context.Context, error) (T, error)) *Future[T] {
+	return Do(f.parent, func(ctx context.Context) (T, error) {
+		value, err := f.wait(ctx)
+		if err == nil {
+			return value, nil
+		}
+
+		if _, ok := err.(*PanicError); ok && f.repanic {
+			return value, err
+		}
+
+		return fn(ctx, err)
+	}, inherit(f.repanic)...)
+}
+
+// Join2 returns a Future whose result contains the values of both given futures once they have completed. If either
+// Future panics, the result is the first *PanicError. Otherwise, if either Future fails, the result is their errors
+// joined. The returned Future inherits the RePanic option if either given Future was created with it.
+func Join2[A, B any](a *Future[A], b *Future[B]) *Future[Pair[A, B]] {
+	return Do(a.parent, func(ctx context.Context) (Pair[A, B], error) {
+		first, errA := a.wait(ctx)
+		second, errB := b.wait(ctx)
+		if err := join(errA, errB); err != nil {
+			return Pair[A, B]{}, err
+		}
+
+		return Pair[A, B]{First: first, Second: second}, nil
+	}, inherit(a.repanic, b.repanic)...)
+}
+
+// Join3 returns a Future whose result contains the values of all three given futures once they have completed. If any
+// Future panics, the result is the first *PanicError. Otherwise, if any Future fails, the result is their errors
+// joined. The returned Future inherits the RePanic option if any given Future was created with it.
+func Join3[A, B, C any](a *Future[A], b *Future[B], c *Future[C]) *Future[Triple[A, B, C]] {
+	return Do(a.parent, func(ctx context.Context) (Triple[A, B, C], error) {
+		first, errA := a.wait(ctx)
+		second, errB := b.wait(ctx)
+		third, errC := c.wait(ctx)
+		if err := join(errA, errB, errC); err != nil {
+			return Triple[A, B, C]{}, err
+		}
+
+		return Triple[A, B, C]{First: first, Second: second, Third: third}, nil
+	}, inherit(a.repanic, b.repanic, c.repanic)...)
+}
+
+// join returns the first *PanicError within errs unchanged, so that it can be re-panicked, or all errs joined.
+func join(errs ...error) error {
+	for _, err := range errs {
+		if p, ok := err.(*PanicError); ok {
+			return p
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,214 @@
+package future_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidsbond/x/future"
+)
+
+func TestThen(t *testing.T) {
+	t.Parallel()
+
+	t.Run("chains futures", func(t *testing.T) {
+		f := future.Then(future.Do(t.Context(), func(ctx context.Context) (int, error) {
+			return 42, nil
+		}), func(ctx context.Context, v int) (string, error) {
+			return strconv.Itoa(v), nil
+		})
+
+		actual, err := f.Result(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, "42", actual)
+	})
+
+	t.Run("propagates errors", func(t *testing.T) {
+		var called bool
+		f := future.Then(future.Do(t.Context(), func(ctx context.Context) (int, error) {
+			return 0, io.EOF
+		}), func(ctx context.Context, v int) (string, error) {
+			called = true
+			return "", nil
+		})
+
+		_, err := f.Result(t.Context())
+		assert.ErrorIs(t, err, io.EOF)
+		assert.False(t, called)
+	})
+}
+
+func TestMap(t *testing.T) {
+	t.Parallel()
+
+	f := future.Map(future.Do(t.Context(), func(ctx context.Context) (int, error) {
+		return 21, nil
+	}), func(v int) int {
+		return v * 2
+	})
+
+	actual, err := f.Result(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 42, actual)
+}
+
+func TestRecover(t *testing.T) {
+	t.Parallel()
+
+	t.Run("provides fallback", func(t *testing.T) {
+		f := future.Recover(future.Do(t.Context(), func(ctx context.Context) (int, error) {
+			return 0, io.EOF
+		}), func(ctx context.Context, err error) (int, error) {
+			if errors.Is(err, io.EOF) {
+				return 42, nil
+			}
+
+			return 0, err
+		})
+
+		actual, err := f.Result(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 42, actual)
+	})
+
+	t.Run("returns successful result", func(t *testing.T) {
+		f := future.Recover(future.Do(t.Context(), func(ctx context.Context) (int, error) {
+			return 1, nil
+		}), func(ctx context.Context, err error) (int, error) {
+			return 42, nil
+		})
+
+		actual, err := f.Result(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 1, actual)
+	})
+}
+
+func TestJoin(t *testing.T) {
+	t.Parallel()
+
+	number := future.Do(t.Context(), func(ctx context.Context) (int, error) {
+		return 42, nil
+	})
+
+	text := future.Do(t.Context(), func(ctx context.Context) (string, error) {
+		return "hello", nil
+	})
+
+	flag := future.Do(t.Context(), func(ctx context.Context) (bool, error) {
+		return true, nil
+	})
+
+	failed := future.Do(t.Context(), func(ctx context.Context) (bool, error) {
+		return false, io.EOF
+	})
+
+	t.Run("joins two futures", func(t *testing.T) {
+		actual, err := future.Join2(number, text).Result(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, future.Pair[int, string]{First: 42, Second: "hello"}, actual)
+	})
+
+	t.Run("joins three futures", func(t *testing.T) {
+		actual, err := future.Join3(number, text, flag).Result(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, future.Triple[int, string, bool]{First: 42, Second: "hello", Third: true}, actual)
+	})
+
+	t.Run("returns errors", func(t *testing.T) {
+		_, err := future.Join3(number, text, failed).Result(t.Context())
+		assert.ErrorIs(t, err, io.EOF)
+	})
+}
+
+func TestChain_Panic(t *testing.T) {
+	t.Parallel()
+
+	panics := func(ctx context.Context) (int, error) {
+		panic("boom")
+	}
+
+	tt := []struct {
+		Name   string
+		Derive func(f *future.Future[int]) func(ctx context.Context) error
+	}{
+		{
+			Name: "then",
+			Derive: func(f *future.Future[int]) func(ctx context.Context) error {
+				return resultOf(future.Then(f, func(ctx context.Context, v int) (int, error) {
+					return v, nil
+				}))
+			},
+		},
+		{
+			Name: "map",
+			Derive: func(f *future.Future[int]) func(ctx context.Context) error {
+				return resultOf(future.Map(f, func(v int) int {
+					return v
+				}))
+			},
+		},
+		{
+			Name: "recover",
+			Derive: func(f *future.Future[int]) func(ctx context.Context) error {
+				return resultOf(future.Recover(f, func(ctx context.Context, err error) (int, error) {
+					return 0, err
+				}))
+			},
+		},
+		{
+			Name: "join2",
+			Derive: func(f *future.Future[int]) func(ctx context.Context) error {
+				return resultOf(future.Join2(future.Do(t.Context(), func(ctx context.Context) (int, error) {
+					return 0, io.EOF
+				}), f))
+			},
+		},
+		{
+			Name: "join3",
+			Derive: func(f *future.Future[int]) func(ctx context.Context) error {
+				return resultOf(future.Join3(f, f, f))
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Run("passes the original panic through", func(t *testing.T) {
+				source := future.Do(t.Context(), panics)
+				_, expected := source.Result(t.Context())
+
+				err := tc.Derive(source)(t.Context())
+				assert.Same(t, expected, err)
+			})
+
+			t.Run("re-panics on the calling goroutine", func(t *testing.T) {
+				result := tc.Derive(future.Do(t.Context(), panics, future.RePanic()))
+
+				defer func() {
+					target, ok := recover().(*future.PanicError)
+					require.True(t, ok)
+					assert.Equal(t, "boom", target.Value)
+					assert.Contains(t, string(target.Stack), "TestChain_Panic")
+					assert.NotContains(t, string(target.Stack), "future.Then")
+				}()
+
+				_ = result(t.Context())
+				assert.Fail(t, "result should panic")
+			})
+		})
+	}
+}
+
+// resultOf returns a function that waits for the result of the Future, discarding its value.
+func resultOf[T any](f *future.Future[T]) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := f.Result(ctx)
+		return err
+	}
+}
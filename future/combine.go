@@ -0,0 +1,153 @@
+package future
+
+import (
+	"context"
+	"errors"
+)
+
+type (
+	// The Outcome type describes the result of a single Func, as returned by AllSettled.
+	Outcome[T any] struct {
+		// The value returned by the Func.
+		Value T
+		// The error returned by the Func.
+		Err error
+	}
+
+	indexed[T any] struct {
+		index int
+		Outcome[T]
+	}
+)
+
+// ErrNoFuncs is the error given when a combinator is called without any Func implementations.
+var ErrNoFuncs = errors.New("no funcs provided")
+
+// Race returns a Future whose result is that of the first provided Func to return, whether it succeeded or failed.
+// Once a Func has returned, the contexts of the remaining Func implementations are cancelled. If no Func
+// implementations are provided, the result is ErrNoFuncs.
+func Race[T any](ctx context.Context, funcs ...Func[T]) *Future[T] {
+	return Do(ctx, func(ctx context.Context) (T, error) {
+		if len(funcs) == 0 {
+			var v T
+			return v, ErrNoFuncs
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		first := <-start(ctx, 0, funcs)
+		return first.Value, first.Err
+	})
+}
+
+// Any returns a Future whose result is the value of the first provided Func to succeed. Once a Func has succeeded, the
+// contexts of the remaining Func implementations are cancelled. If every Func fails, the result is the errors of each
+// Func joined in the order the Func implementations were provided. If no Func implementations are provided, the result
+// is ErrNoFuncs.
+func Any[T any](ctx context.Context, funcs ...Func[T]) *Future[T] {
+	return Do(ctx, func(ctx context.Context) (T, error) {
+		var v T
+		if len(funcs) == 0 {
+			return v, ErrNoFuncs
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		results := start(ctx, 0, funcs)
+		errs := make([]error, len(funcs))
+		for range funcs {
+			result := <-results
+			if result.Err == nil {
+				return result.Value, nil
+			}
+
+			errs[result.index] = result.Err
+		}
+
+		return v, errors.Join(errs...)
+	})
+}
+
+// AllSettled returns a Future whose result contains the Outcome of every provided Func, in the order the Func
+// implementations were provided. The Future completes once every Func has returned and never returns an error itself.
+func AllSettled[T any](ctx context.Context, funcs ...Func[T]) *Future[[]Outcome[T]] {
+	return Do(ctx, func(ctx context.Context) ([]Outcome[T], error) {
+		results := start(ctx, 0, funcs)
+		out := make([]Outcome[T], len(funcs))
+		for range funcs {
+			result := <-results
+			out[result.index] = result.Outcome
+		}
+
+		return out, nil
+	})
+}
+
+// AllOrFirstError returns a Future whose result contains the values of every provided Func, in the order the Func
+// implementations were provided. If any Func fails, the contexts of the remaining Func implementations are cancelled
+// and the result is the first error to occur.
+func AllOrFirstError[T any](ctx context.Context, funcs ...Func[T]) *Future[[]T] {
+	return Do(ctx, func(ctx context.Context) ([]T, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		results := start(ctx, 0, funcs)
+		out := make([]T, len(funcs))
+		for range funcs {
+			result := <-results
+			if result.Err != nil {
+				return nil, result.Err
+			}
+
+			out[result.index] = result.Value
+		}
+
+		return out, nil
+	})
+}
+
+// start creates a Future for each Func, returning a channel that receives the result of each as they complete. At most
+// limit Future implementations run at once, if limit is less than one there is no limit. Func implementations that
+// have not started when the context is cancelled are not invoked and their result is the context's error. The channel
+// is buffered so that results that are never received do not prevent goroutines from exiting.
+func start[T any](ctx context.Context, limit int, funcs []Func[T]) <-chan indexed[T] {
+	results := make(chan indexed[T], len(funcs))
+	if limit < 1 || limit > len(funcs) {
+		limit = len(funcs)
+	}
+
+	sem := make(chan struct{}, limit)
+	go func() {
+		for i, fn := range funcs {
+			if !acquire(ctx, sem) {
+				results <- indexed[T]{index: i, Outcome: Outcome[T]{Err: ctx.Err()}}
+				continue
+			}
+
+			f := Do(ctx, fn)
+			go func() {
+				<-f.Done()
+				<-sem
+				results <- indexed[T]{index: i, Outcome: Outcome[T]{Value: f.value, Err: f.err}}
+			}()
+		}
+	}()
+
+	return results
+}
+
+// acquire a slot within the semaphore, returning false if the context is cancelled first.
+func acquire(ctx context.Context, sem chan struct{}) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case sem <- struct{}{}:
+		return true
+	}
+}
@@ -0,0 +1,157 @@
+package future_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidsbond/x/future"
+)
+
+// after returns a Func that returns the value and error after the given delay, or the context's error if it is
+// cancelled first.
+func after[T any](delay time.Duration, value T, err error) future.Func[T] {
+	return func(ctx context.Context) (T, error) {
+		select {
+		case <-ctx.Done():
+			var v T
+			return v, ctx.Err()
+		case <-time.After(delay):
+			return value, err
+		}
+	}
+}
+
+// blocked returns a Func that blocks until its context is cancelled, reporting the cancellation on the channel.
+func blocked[T any](cancelled chan<- struct{}) future.Func[T] {
+	return func(ctx context.Context) (T, error) {
+		<-ctx.Done()
+		close(cancelled)
+
+		var v T
+		return v, ctx.Err()
+	}
+}
+
+func TestRace(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns first result", func(t *testing.T) {
+		cancelled := make(chan struct{})
+
+		actual, err := future.Race(t.Context(),
+			blocked[int](cancelled),
+			after(0, 42, nil),
+		).Result(t.Context())
+
+		require.NoError(t, err)
+		assert.Equal(t, 42, actual)
+		<-cancelled
+	})
+
+	t.Run("returns first error", func(t *testing.T) {
+		_, err := future.Race(t.Context(),
+			after(time.Minute, 1, nil),
+			after(0, 0, io.EOF),
+		).Result(t.Context())
+
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("returns error when no funcs", func(t *testing.T) {
+		_, err := future.Race[int](t.Context()).Result(t.Context())
+		assert.ErrorIs(t, err, future.ErrNoFuncs)
+	})
+}
+
+func TestAny(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns first success", func(t *testing.T) {
+		cancelled := make(chan struct{})
+
+		actual, err := future.Any(t.Context(),
+			after(0, 0, io.EOF),
+			blocked[int](cancelled),
+			after(10*time.Millisecond, 42, nil),
+		).Result(t.Context())
+
+		require.NoError(t, err)
+		assert.Equal(t, 42, actual)
+		<-cancelled
+	})
+
+	t.Run("returns all errors", func(t *testing.T) {
+		errOther := errors.New("other")
+
+		_, err := future.Any(t.Context(),
+			after(10*time.Millisecond, 0, io.EOF),
+			after(0, 0, errOther),
+		).Result(t.Context())
+
+		assert.ErrorIs(t, err, io.EOF)
+		assert.ErrorIs(t, err, errOther)
+		assert.EqualValues(t, "EOF\nother", err.Error())
+	})
+
+	t.Run("returns error when no funcs", func(t *testing.T) {
+		_, err := future.Any[int](t.Context()).Result(t.Context())
+		assert.ErrorIs(t, err, future.ErrNoFuncs)
+	})
+}
+
+func TestAllSettled(t *testing.T) {
+	t.Parallel()
+
+	actual, err := future.AllSettled(t.Context(),
+		after(10*time.Millisecond, 1, nil),
+		after(0, 0, io.EOF),
+		after(0, 3, nil),
+	).Result(t.Context())
+
+	require.NoError(t, err)
+	assert.Equal(t, []future.Outcome[int]{
+		{Value: 1},
+		{Err: io.EOF},
+		{Value: 3},
+	}, actual)
+}
+
+func TestAllOrFirstError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns all values", func(t *testing.T) {
+		actual, err := future.AllOrFirstError(t.Context(),
+			after(10*time.Millisecond, 1, nil),
+			after(0, 2, nil),
+		).Result(t.Context())
+
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, actual)
+	})
+
+	t.Run("returns first error", func(t *testing.T) {
+		cancelled := make(chan struct{})
+
+		actual, err := future.AllOrFirstError(t.Context(),
+			after(0, 1, nil),
+			blocked[int](cancelled),
+			after(10*time.Millisecond, 0, io.EOF),
+		).Result(t.Context())
+
+		assert.ErrorIs(t, err, io.EOF)
+		assert.Nil(t, actual)
+		<-cancelled
+	})
+
+	t.Run("returns empty slice when no funcs", func(t *testing.T) {
+		actual, err := future.AllOrFirstError[int](t.Context()).Result(t.Context())
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+}
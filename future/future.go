@@ -4,67 +4,271 @@ package future
 
 import (
 	"context"
+	"fmt"
 	"iter"
+	"runtime/debug"
 )
 
 type (
 	// The Future type is used to perform an asynchronous action and obtain its result at a later time.
 	Future[T any] struct {
-		signal chan result[T]
+		parent  context.Context
+		done    chan struct{}
+		cancel  context.CancelFunc
+		value   T
+		err     error
+		repanic bool
 	}
 
 	// The Func type represents the action the future should take upon creation.
 	Func[T any] func(ctx context.Context) (T, error)
 
-	result[T any] struct {
-		value T
-		err   error
+	// The Option type is a function that modifies the behaviour of a Future.
+	Option func(*options)
+
+	// The PanicError type is the error returned by Future.Result when the Func of the Future panics.
+	PanicError struct {
+		// The value the Func panicked with.
+		Value any
+		// The stack trace of the goroutine that panicked, at the point of the panic.
+		Stack []byte
+	}
+
+	options struct {
+		repanic bool
 	}
 )
 
+// RePanic returns an Option that causes Future.Result and Future.TryResult to panic with the *PanicError on the calling
+// goroutine when the Func of the Future panics, rather than returning it as an error. Futures derived using Then, Map,
+// Recover, Join2 and Join3 inherit the option from the futures they are derived from. Combinators such as Race, Any,
+// AllSettled, AllOrFirstError, All and Completed accept Func implementations rather than futures, so they always return
+// panics as a *PanicError.
+func RePanic() Option {
+	return func(o *options) {
+		o.repanic = true
+	}
+}
+
 // Do returns a new Future that executes the given Func implementation. The function is invoked immediately within
-// its own goroutine and its return value is made accessible via the Result method. The provided context is passed
-// into the Func call for cancellation.
-func Do[T any](ctx context.Context, fn Func[T]) *Future[T] {
+// its own goroutine and its return value is made accessible via the Result method. The Func is given a context derived
+// from the one provided, which is cancelled when the provided context is cancelled, when Future.Cancel is called or
+// once the Func has returned. If the Func panics, the panic is recovered and returned from Result as a *PanicError,
+// unless the RePanic option is provided.
+func Do[T any](parent context.Context, fn Func[T], opts ...Option) *Future[T] {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+
 	f := &Future[T]{
-		signal: make(chan result[T], 1),
+		parent:  parent,
+		done:    make(chan struct{}),
+		cancel:  cancel,
+		repanic: o.repanic,
 	}
 
 	go func() {
-		defer close(f.signal)
-		value, err := fn(ctx)
-		f.signal <- result[T]{value: value, err: err}
+		defer cancel()
+
+		// The result is written before the done channel is closed, which makes it visible to any goroutine that has
+		// observed the closure.
+		f.value, f.err = call(ctx, fn)
+		close(f.done)
 	}()
 
 	return f
 }
 
+// inherit returns the options a Future derived from futures with the given repanic settings is created with.
+func inherit(repanic ...bool) []Option {
+	for _, r := range repanic {
+		if r {
+			return []Option{RePanic()}
+		}
+	}
+
+	return nil
+}
+
+// call invokes the Func, converting any panic into a *PanicError. A *PanicError re-panicked by Future.Result is passed
+// through as-is, so that it retains the stack trace of the goroutine that originally panicked.
+func call[T any](ctx context.Context, fn Func[T]) (value T, err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		var v T
+		if p, ok := r.(*PanicError); ok {
+			value, err = v, p
+			return
+		}
+
+		value, err = v, &PanicError{Value: r, Stack: debug.Stack()}
+	}()
+
+	return fn(ctx)
+}
+
 // All creates a Future for each provided Func, running each in its own goroutine. Results of each Future are exposed
 // via an iter.Seq2 that can be ranged over. The first value of the iter.Seq2 is the return type of the Future, the
-// second is any error that has occurred.
+// second is any error that has occurred. Results are yielded in the order the Func implementations were provided. If
+// iteration stops early, the contexts of any Func implementations that are still running are cancelled.
 func All[T any](ctx context.Context, funcs ...Func[T]) iter.Seq2[T, error] {
+	return AllLimit(ctx, 0, funcs...)
+}
+
+// AllLimit behaves like All but runs at most limit Func implementations at once, starting the next as each one
+// returns. If limit is less than one, there is no limit. If the provided context is cancelled, the context's error is
+// yielded for each Func whose result has not yet arrived, without waiting for it to return.
+func AllLimit[T any](parent context.Context, limit int, funcs ...Func[T]) iter.Seq2[T, error] {
 	return func(yield func(T, error) bool) {
-		futures := make([]*Future[T], len(funcs))
-		for i, f := range funcs {
-			futures[i] = Do(ctx, f)
+		ctx, cancel := context.WithCancel(parent)
+		defer cancel()
+
+		results := start(ctx, limit, funcs)
+
+		// Results arrive in completion order, so any that arrive before those preceding them are held until they can
+		// be yielded in order.
+		pending := make(map[int]Outcome[T])
+		for next := 0; next < len(funcs); next++ {
+			outcome, ok := pending[next]
+			for !ok {
+				select {
+				case <-parent.Done():
+					outcome, ok = Outcome[T]{Err: parent.Err()}, true
+				case result := <-results:
+					pending[result.index] = result.Outcome
+					outcome, ok = pending[next]
+				}
+			}
+
+			delete(pending, next)
+			if !yield(outcome.Value, outcome.Err) {
+				return
+			}
 		}
+	}
+}
+
+// Completed creates a Future for each provided Func, running at most limit at once. If limit is less than one, there
+// is no limit. Results of each Future are exposed via an iter.Seq2 in the order that they complete, so fast results
+// are not delayed behind slow ones. The first value of the iter.Seq2 is the index of the Func that produced the
+// result, the second is its Outcome. If iteration stops early, the contexts of any Func implementations that are still
+// running are cancelled. If the provided context is cancelled, the context's error is yielded for each Func whose
+// result has not yet arrived, without waiting for it to return.
+func Completed[T any](parent context.Context, limit int, funcs ...Func[T]) iter.Seq2[int, Outcome[T]] {
+	return func(yield func(int, Outcome[T]) bool) {
+		ctx, cancel := context.WithCancel(parent)
+		defer cancel()
+
+		results := start(ctx, limit, funcs)
+		delivered := make([]bool, len(funcs))
+		for range funcs {
+			select {
+			case <-parent.Done():
+				// The remaining results are not waited for, as their Func implementations may not observe the
+				// cancellation of their context.
+				for i, ok := range delivered {
+					if !ok && !yield(i, Outcome[T]{Err: parent.Err()}) {
+						return
+					}
+				}
 
-		for _, future := range futures {
-			if !yield(future.Result(ctx)) {
 				return
+			case result := <-results:
+				delivered[result.index] = true
+				if !yield(result.index, result.Outcome) {
+					return
+				}
 			}
 		}
 	}
 }
 
 // Result returns the result of the Future. If the invoked Func is still in-progress, this method blocks until the
-// Func has returned or the provided context is cancelled.
+// Func has returned or the provided context is cancelled. The result is retained, so Result may be called any number
+// of times from any number of goroutines and will always return the same value and error.
 func (f *Future[T]) Result(ctx context.Context) (T, error) {
+	value, err := f.wait(ctx)
+	f.rethrow(err)
+	return value, err
+}
+
+// TryResult returns the result of the Future without blocking. The boolean return value is false if the invoked Func
+// is still in-progress, in which case the value and error are zero.
+func (f *Future[T]) TryResult() (T, bool, error) {
+	select {
+	case <-f.done:
+		f.rethrow(f.err)
+		return f.value, true, f.err
+	default:
+		var v T
+		return v, false, nil
+	}
+}
+
+// IsDone returns true if the invoked Func has returned.
+func (f *Future[T]) IsDone() bool {
+	select {
+	case <-f.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Cancel the context passed to the Func of the Future without affecting the context the Future was created with. This
+// method does not wait for the Func to return, use Done or Result to wait for it. It is up to the Func to observe the
+// cancellation of its context. Calling Cancel after the Func has returned has no effect.
+func (f *Future[T]) Cancel() {
+	f.cancel()
+}
+
+// Done returns a channel that is closed once the Func of the Future has returned, allowing the Future to be used
+// within a select statement.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// wait blocks until the Func has returned or the context is cancelled, returning the result without re-panicking.
+func (f *Future[T]) wait(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	default:
+	}
+
 	select {
 	case <-ctx.Done():
 		var v T
 		return v, ctx.Err()
-	case r := <-f.signal:
-		return r.value, r.err
+	case <-f.done:
+		return f.value, f.err
 	}
 }
+
+// rethrow panics with the error if it is a *PanicError and the Future was created with the RePanic option.
+func (f *Future[T]) rethrow(err error) {
+	if p, ok := err.(*PanicError); ok && f.repanic {
+		panic(p)
+	}
+}
+
+// Error returns the value the Func panicked with, followed by the stack trace.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("future: panic: %v\n\n%s", e.Value, e.Stack)
+}
+
+// Unwrap returns the value the Func panicked with if it is an error.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+
+	return nil
+}
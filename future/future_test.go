@@ -3,7 +3,12 @@ package future_test
 import (
 	"context"
 	"io"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -90,3 +95,359 @@ func TestAll(t *testing.T) {
 		assert.Equal(t, 2, i)
 	})
 }
+
+func TestFuture_Cancel(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cancels the func", func(t *testing.T) {
+		f := future.Do(t.Context(), func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		})
+
+		f.Cancel()
+
+		_, err := f.Result(t.Context())
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.NoError(t, t.Context().Err())
+	})
+
+	t.Run("does not affect other futures", func(t *testing.T) {
+		release := make(chan struct{})
+
+		a := future.Do(t.Context(), func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		})
+
+		b := future.Do(t.Context(), func(ctx context.Context) (int, error) {
+			<-release
+			return 42, ctx.Err()
+		})
+
+		a.Cancel()
+		<-a.Done()
+		close(release)
+
+		actual, err := b.Result(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 42, actual)
+	})
+}
+
+func TestFuture_Done(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	f := future.Do(t.Context(), func(ctx context.Context) (int, error) {
+		<-release
+		return 42, nil
+	})
+
+	select {
+	case <-f.Done():
+		assert.Fail(t, "future should not be done")
+	default:
+	}
+
+	close(release)
+
+	select {
+	case <-f.Done():
+	case <-time.After(time.Second):
+		assert.Fail(t, "future should be done")
+	}
+
+	actual, err := f.Result(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 42, actual)
+}
+
+func TestFuture_Result(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns the same result to every call", func(t *testing.T) {
+		f := future.Do(t.Context(), func(ctx context.Context) (int, error) {
+			return 42, io.EOF
+		})
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				actual, err := f.Result(t.Context())
+				assert.Equal(t, 42, actual)
+				assert.Equal(t, io.EOF, err)
+			}()
+		}
+
+		wg.Wait()
+
+		actual, err := f.Result(t.Context())
+		assert.Equal(t, 42, actual)
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("returns completed result when context is cancelled", func(t *testing.T) {
+		f := future.Do(t.Context(), func(ctx context.Context) (int, error) {
+			return 42, nil
+		})
+
+		<-f.Done()
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		actual, err := f.Result(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 42, actual)
+	})
+}
+
+func TestFuture_TryResult(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	f := future.Do(t.Context(), func(ctx context.Context) (int, error) {
+		<-release
+		return 42, nil
+	})
+
+	actual, ok, err := f.TryResult()
+	assert.False(t, ok)
+	assert.False(t, f.IsDone())
+	assert.Zero(t, actual)
+	assert.NoError(t, err)
+
+	close(release)
+	<-f.Done()
+
+	actual, ok, err = f.TryResult()
+	assert.True(t, ok)
+	assert.True(t, f.IsDone())
+	assert.Equal(t, 42, actual)
+	assert.NoError(t, err)
+}
+
+func TestAllLimit(t *testing.T) {
+	t.Parallel()
+
+	t.Run("limits concurrency", func(t *testing.T) {
+		const limit = 3
+
+		var running, peak atomic.Int64
+		funcs := make([]future.Func[int], 20)
+		for i := range funcs {
+			funcs[i] = func(ctx context.Context) (int, error) {
+				n := running.Add(1)
+				defer running.Add(-1)
+
+				for {
+					current := peak.Load()
+					if n <= current || peak.CompareAndSwap(current, n) {
+						break
+					}
+				}
+
+				time.Sleep(time.Millisecond)
+				return i, nil
+			}
+		}
+
+		var actual []int
+		for result, err := range future.AllLimit(t.Context(), limit, funcs...) {
+			require.NoError(t, err)
+			actual = append(actual, result)
+		}
+
+		assert.Len(t, actual, len(funcs))
+		assert.True(t, slices.IsSorted(actual))
+		assert.LessOrEqual(t, peak.Load(), int64(limit))
+	})
+
+	t.Run("cancels remaining funcs when iteration stops", func(t *testing.T) {
+		cancelled := make(chan struct{})
+
+		results := future.AllLimit(t.Context(), 0,
+			func(ctx context.Context) (int, error) {
+				return 1, nil
+			},
+			func(ctx context.Context) (int, error) {
+				<-ctx.Done()
+				close(cancelled)
+				return 0, ctx.Err()
+			},
+		)
+
+		for range results {
+			break
+		}
+
+		<-cancelled
+	})
+}
+
+func TestCompleted(t *testing.T) {
+	t.Parallel()
+
+	t.Run("yields in completion order", func(t *testing.T) {
+		release := make(chan struct{})
+
+		results := future.Completed(t.Context(), 0,
+			func(ctx context.Context) (string, error) {
+				<-release
+				return "slow", nil
+			},
+			func(ctx context.Context) (string, error) {
+				return "fast", nil
+			},
+		)
+
+		var indexes []int
+		for index, outcome := range results {
+			require.NoError(t, outcome.Err)
+			if index == 1 {
+				assert.Equal(t, "fast", outcome.Value)
+				close(release)
+			} else {
+				assert.Equal(t, "slow", outcome.Value)
+			}
+
+			indexes = append(indexes, index)
+		}
+
+		assert.Equal(t, []int{1, 0}, indexes)
+	})
+
+	t.Run("does not start funcs after cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+
+		var calls atomic.Int64
+		fn := func(ctx context.Context) (int, error) {
+			calls.Add(1)
+			cancel()
+			return 0, nil
+		}
+
+		var errs int
+		for _, outcome := range future.Completed(ctx, 1, fn, fn, fn) {
+			if outcome.Err != nil {
+				assert.ErrorIs(t, outcome.Err, context.Canceled)
+				errs++
+			}
+		}
+
+		assert.EqualValues(t, 1, calls.Load())
+		// The result of the func that was started may not arrive before the cancellation is observed.
+		assert.GreaterOrEqual(t, errs, 2)
+	})
+}
+
+func TestDo_Panic(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns panics as errors", func(t *testing.T) {
+		f := future.Do(t.Context(), func(ctx context.Context) (int, error) {
+			panic("boom")
+		})
+
+		actual, err := f.Result(t.Context())
+		assert.Zero(t, actual)
+
+		var target *future.PanicError
+		require.ErrorAs(t, err, &target)
+		assert.Equal(t, "boom", target.Value)
+		assert.Contains(t, string(target.Stack), "future_test.go")
+		assert.True(t, strings.HasPrefix(err.Error(), "future: panic: boom\n"))
+	})
+
+	t.Run("unwraps error values", func(t *testing.T) {
+		f := future.Do(t.Context(), func(ctx context.Context) (int, error) {
+			panic(io.EOF)
+		})
+
+		_, err := f.Result(t.Context())
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("re-panics on the calling goroutine", func(t *testing.T) {
+		f := future.Do(t.Context(), func(ctx context.Context) (int, error) {
+			panic("boom")
+		}, future.RePanic())
+
+		<-f.Done()
+
+		defer func() {
+			target, ok := recover().(*future.PanicError)
+			require.True(t, ok)
+			assert.Equal(t, "boom", target.Value)
+		}()
+
+		_, _ = f.Result(t.Context())
+		assert.Fail(t, "result should panic")
+	})
+
+	t.Run("propagates panics through combinators", func(t *testing.T) {
+		_, err := future.AllOrFirstError(t.Context(), func(ctx context.Context) (int, error) {
+			panic("boom")
+		}).Result(t.Context())
+
+		var target *future.PanicError
+		assert.ErrorAs(t, err, &target)
+	})
+}
+
+func TestAll_Cancellation(t *testing.T) {
+	t.Parallel()
+
+	// stubborn returns a Func that ignores its context, only returning once the test has finished.
+	stubborn := func(t *testing.T) future.Func[int] {
+		release := make(chan struct{})
+		t.Cleanup(func() { close(release) })
+
+		return func(ctx context.Context) (int, error) {
+			<-release
+			return 0, nil
+		}
+	}
+
+	fast := func(ctx context.Context) (int, error) {
+		return 42, nil
+	}
+
+	t.Run("in order", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+		defer cancel()
+
+		var (
+			values []int
+			errs   []error
+		)
+
+		for value, err := range future.All(ctx, fast, stubborn(t), fast) {
+			values = append(values, value)
+			errs = append(errs, err)
+		}
+
+		require.Len(t, errs, 3)
+		assert.Equal(t, 42, values[0])
+		assert.NoError(t, errs[0])
+		assert.ErrorIs(t, errs[1], context.DeadlineExceeded)
+	})
+
+	t.Run("in completion order", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+		defer cancel()
+
+		outcomes := make(map[int]future.Outcome[int])
+		for index, outcome := range future.Completed(ctx, 0, stubborn(t), fast) {
+			outcomes[index] = outcome
+		}
+
+		require.Len(t, outcomes, 2)
+		assert.Equal(t, future.Outcome[int]{Value: 42}, outcomes[1])
+		assert.ErrorIs(t, outcomes[0].Err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,262 @@
+package future
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+type (
+	// The Policy type is a function that wraps a Func to modify how it is invoked, such as retrying it or limiting how
+	// long it may run for. Policies are combined using Apply.
+	Policy[T any] func(Func[T]) Func[T]
+
+	// The Clock interface describes types that measure the passing of time for policies. It allows tests to control
+	// time rather than waiting on timers. The SystemClock is used unless the WithClock option is provided.
+	Clock interface {
+		// After waits for the duration to elapse and then sends the current time on the returned channel.
+		After(d time.Duration) <-chan time.Time
+	}
+
+	// The Backoff type describes how long Retry waits between attempts. The delay before the second attempt is
+	// Initial, with each subsequent delay multiplied by Multiplier up to Max. Jitter randomly reduces each delay by up
+	// to the given fraction, so that callers retrying at the same time spread out their attempts.
+	Backoff struct {
+		// The delay before the second attempt.
+		Initial time.Duration
+		// The maximum delay between attempts. If zero, there is no maximum.
+		Max time.Duration
+		// The factor the delay grows by after each attempt. If less than one, a factor of two is used.
+		Multiplier float64
+		// The fraction, between zero and one, that each delay may be randomly reduced by.
+		Jitter float64
+	}
+
+	// The PolicyOption type is a function that modifies the behaviour of a Policy.
+	PolicyOption func(*policyOptions)
+
+	policyOptions struct {
+		clock     Clock
+		retryable func(error) bool
+	}
+
+	systemClock struct{}
+)
+
+// SystemClock is a Clock that uses the system time.
+var SystemClock Clock = systemClock{}
+
+// WithClock returns a PolicyOption that uses the given Clock to measure the passing of time rather than the
+// SystemClock.
+func WithClock(clock Clock) PolicyOption {
+	return func(o *policyOptions) {
+		o.clock = clock
+	}
+}
+
+// RetryIf returns a PolicyOption that determines which errors are retried by Retry. By default, all errors are retried
+// until the context given to the Func is cancelled.
+func RetryIf(fn func(error) bool) PolicyOption {
+	return func(o *policyOptions) {
+		o.retryable = fn
+	}
+}
+
+// Apply wraps the Func with each of the given policies. The first policy given is the outermost, so policies apply to
+// everything given after them. For example, to retry a Func with a timeout applied to each attempt:
+//
+//	fn = future.Apply(fn, future.Retry[T](3, backoff), future.Timeout[T](time.Second))
+func Apply[T any](fn Func[T], policies ...Policy[T]) Func[T] {
+	for i := len(policies) - 1; i >= 0; i-- {
+		fn = policies[i](fn)
+	}
+
+	return fn
+}
+
+// Timeout returns a Policy that cancels the context given to the Func once the duration has elapsed. If the Func
+// returns an error after this, the error is replaced with context.DeadlineExceeded. When using the SystemClock, the
+// context is given a deadline so that it can be passed on to clients that observe deadlines. Otherwise, the context
+// has no deadline but is cancelled with context.DeadlineExceeded as its cause once the Clock reports the duration has
+// elapsed.
+func Timeout[T any](d time.Duration, opts ...PolicyOption) Policy[T] {
+	o := newPolicyOptions(opts)
+
+	return func(fn Func[T]) Func[T] {
+		return func(parent context.Context) (T, error) {
+			var ctx context.Context
+			if o.clock == SystemClock {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(parent, d)
+				defer cancel()
+			} else {
+				var cancel context.CancelCauseFunc
+				ctx, cancel = context.WithCancelCause(parent)
+				defer cancel(nil)
+
+				timer := o.clock.After(d)
+				go func() {
+					select {
+					case <-ctx.Done():
+					case <-timer:
+						cancel(context.DeadlineExceeded)
+					}
+				}()
+			}
+
+			value, err := fn(ctx)
+			if err == nil || parent.Err() != nil || !errors.Is(context.Cause(ctx), context.DeadlineExceeded) {
+				return value, err
+			}
+
+			var v T
+			return v, context.DeadlineExceeded
+		}
+	}
+}
+
+// Retry returns a Policy that invokes the Func up to the given number of attempts, waiting between each as described
+// by the Backoff, until it succeeds. Use the RetryIf option to limit which errors are retried. If the context given to
+// the Func is cancelled while waiting, the context's error is returned. Otherwise, the error of the last attempt is
+// returned.
+func Retry[T any](attempts int, backoff Backoff, opts ...PolicyOption) Policy[T] {
+	o := newPolicyOptions(opts)
+
+	return func(fn Func[T]) Func[T] {
+		return func(ctx context.Context) (T, error) {
+			var (
+				value T
+				err   error
+			)
+
+			for attempt := range max(attempts, 1) {
+				if attempt > 0 {
+					select {
+					case <-ctx.Done():
+						var v T
+						return v, ctx.Err()
+					case <-o.clock.After(backoff.delay(attempt)):
+					}
+				}
+
+				value, err = fn(ctx)
+				if err == nil || ctx.Err() != nil || !o.retryable(err) {
+					return value, err
+				}
+			}
+
+			return value, err
+		}
+	}
+}
+
+// Hedge returns a Policy that invokes the Func and, if it has not succeeded after the given delay, invokes it again
+// without cancelling the first attempt. This repeats for up to the given number of additional attempts. The result of
+// the first attempt to succeed is returned and the contexts of the remaining attempts are cancelled. If every running
+// attempt fails before the delay has elapsed, the next attempt is started immediately. If all attempts fail, their
+// errors are returned joined.
+func Hedge[T any](delay time.Duration, hedges int, opts ...PolicyOption) Policy[T] {
+	o := newPolicyOptions(opts)
+
+	return func(fn Func[T]) Func[T] {
+		return func(ctx context.Context) (T, error) {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			total := max(hedges, 0) + 1
+			results := make(chan Outcome[T], total)
+
+			var (
+				started, running int
+				timer            <-chan time.Time
+				errs             []error
+			)
+
+			launch := func() {
+				started++
+				running++
+				go func() {
+					value, err := call(ctx, fn)
+					results <- Outcome[T]{Value: value, Err: err}
+				}()
+
+				timer = nil
+				if started < total {
+					timer = o.clock.After(delay)
+				}
+			}
+
+			launch()
+			for {
+				select {
+				case <-ctx.Done():
+					var v T
+					return v, ctx.Err()
+				case <-timer:
+					launch()
+				case result := <-results:
+					running--
+					if result.Err == nil {
+						return result.Value, nil
+					}
+
+					errs = append(errs, result.Err)
+					switch {
+					case running > 0:
+					case started < total:
+						launch()
+					default:
+						var v T
+						return v, errors.Join(errs...)
+					}
+				}
+			}
+		}
+	}
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// delay returns how long to wait before the given attempt, where the first attempt is zero.
+func (b Backoff) delay(attempt int) time.Duration {
+	multiplier := b.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	delay := float64(b.Initial)
+	for range attempt - 1 {
+		delay *= multiplier
+		if b.Max > 0 && delay >= float64(b.Max) {
+			break
+		}
+	}
+
+	if b.Max > 0 {
+		delay = min(delay, float64(b.Max))
+	}
+
+	if b.Jitter > 0 {
+		delay -= delay * min(b.Jitter, 1) * rand.Float64()
+	}
+
+	return time.Duration(delay)
+}
+
+func newPolicyOptions(opts []PolicyOption) policyOptions {
+	o := policyOptions{
+		clock: SystemClock,
+		retryable: func(error) bool {
+			return true
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
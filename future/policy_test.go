@@ -0,0 +1,309 @@
+package future_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidsbond/x/future"
+)
+
+type (
+	// The manualClock type is a future.Clock whose time only passes when Advance is called. Each call to After is
+	// reported on the requested channel so that tests can wait for a policy to start waiting.
+	manualClock struct {
+		mux       sync.Mutex
+		now       time.Duration
+		timers    []manualTimer
+		requested chan time.Duration
+	}
+
+	manualTimer struct {
+		at time.Duration
+		ch chan time.Time
+	}
+)
+
+func newManualClock() *manualClock {
+	return &manualClock{
+		requested: make(chan time.Duration, 100),
+	}
+}
+
+func (c *manualClock) After(d time.Duration) <-chan time.Time {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	ch := make(chan time.Time, 1)
+	c.timers = append(c.timers, manualTimer{at: c.now + d, ch: ch})
+	c.requested <- d
+
+	return ch
+}
+
+// Advance waits for a call to After and then moves time forward by the duration it requested, returning it.
+func (c *manualClock) Advance(t *testing.T) time.Duration {
+	t.Helper()
+
+	var d time.Duration
+	select {
+	case d = <-c.requested:
+	case <-time.After(time.Second):
+		require.Fail(t, "timed out waiting for the clock to be used")
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.now += d
+
+	remaining := c.timers[:0]
+	for _, timer := range c.timers {
+		if timer.at > c.now {
+			remaining = append(remaining, timer)
+			continue
+		}
+
+		timer.ch <- time.Time{}
+	}
+
+	c.timers = remaining
+	return d
+}
+
+func TestTimeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cancels slow funcs", func(t *testing.T) {
+		clock := newManualClock()
+
+		fn := future.Apply(func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, context.Cause(ctx)
+		}, future.Timeout[int](time.Second, future.WithClock(clock)))
+
+		f := future.Do(t.Context(), fn)
+		assert.Equal(t, time.Second, clock.Advance(t))
+
+		_, err := f.Result(t.Context())
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("sets a deadline using the system clock", func(t *testing.T) {
+		var (
+			deadline time.Time
+			ok       bool
+		)
+
+		fn := future.Apply(func(ctx context.Context) (int, error) {
+			deadline, ok = ctx.Deadline()
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}, future.Timeout[int](10*time.Millisecond))
+
+		start := time.Now()
+		_, err := future.Do(t.Context(), fn).Result(t.Context())
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		require.True(t, ok)
+		assert.WithinDuration(t, start.Add(10*time.Millisecond), deadline, time.Second)
+	})
+
+	t.Run("returns result of fast funcs", func(t *testing.T) {
+		clock := newManualClock()
+
+		fn := future.Apply(func(ctx context.Context) (int, error) {
+			return 42, nil
+		}, future.Timeout[int](time.Second, future.WithClock(clock)))
+
+		actual, err := future.Do(t.Context(), fn).Result(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 42, actual)
+	})
+}
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+
+	backoff := future.Backoff{
+		Initial: 100 * time.Millisecond,
+		Max:     250 * time.Millisecond,
+	}
+
+	// failing returns a Func that fails the given number of times before succeeding.
+	failing := func(failures int64, calls *atomic.Int64) future.Func[int] {
+		return func(ctx context.Context) (int, error) {
+			if calls.Add(1) <= failures {
+				return 0, io.EOF
+			}
+
+			return 42, nil
+		}
+	}
+
+	t.Run("retries with backoff", func(t *testing.T) {
+		clock := newManualClock()
+
+		var calls atomic.Int64
+		fn := future.Apply(failing(3, &calls), future.Retry[int](5, backoff, future.WithClock(clock)))
+		f := future.Do(t.Context(), fn)
+
+		assert.Equal(t, 100*time.Millisecond, clock.Advance(t))
+		assert.Equal(t, 200*time.Millisecond, clock.Advance(t))
+		assert.Equal(t, 250*time.Millisecond, clock.Advance(t))
+
+		actual, err := f.Result(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 42, actual)
+		assert.EqualValues(t, 4, calls.Load())
+	})
+
+	t.Run("returns last error", func(t *testing.T) {
+		clock := newManualClock()
+
+		var calls atomic.Int64
+		fn := future.Apply(failing(3, &calls), future.Retry[int](2, backoff, future.WithClock(clock)))
+		f := future.Do(t.Context(), fn)
+		clock.Advance(t)
+
+		_, err := f.Result(t.Context())
+		assert.ErrorIs(t, err, io.EOF)
+		assert.EqualValues(t, 2, calls.Load())
+	})
+
+	t.Run("does not retry unretryable errors", func(t *testing.T) {
+		var calls atomic.Int64
+		fn := future.Apply(failing(3, &calls), future.Retry[int](5, backoff, future.RetryIf(func(err error) bool {
+			return !errors.Is(err, io.EOF)
+		})))
+
+		_, err := future.Do(t.Context(), fn).Result(t.Context())
+		assert.ErrorIs(t, err, io.EOF)
+		assert.EqualValues(t, 1, calls.Load())
+	})
+
+	t.Run("applies jitter", func(t *testing.T) {
+		clock := newManualClock()
+
+		var calls atomic.Int64
+		jittered := future.Backoff{Initial: 100 * time.Millisecond, Jitter: 0.5}
+		fn := future.Apply(failing(1, &calls), future.Retry[int](2, jittered, future.WithClock(clock)))
+		f := future.Do(t.Context(), fn)
+
+		delay := clock.Advance(t)
+		assert.GreaterOrEqual(t, delay, 50*time.Millisecond)
+		assert.LessOrEqual(t, delay, 100*time.Millisecond)
+
+		_, err := f.Result(t.Context())
+		require.NoError(t, err)
+	})
+
+	t.Run("stops when cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+
+		clock := newManualClock()
+
+		var calls atomic.Int64
+		fn := future.Apply(failing(3, &calls), future.Retry[int](5, backoff, future.WithClock(clock)))
+		f := future.Do(ctx, fn)
+
+		// Wait for the first attempt to fail before cancelling.
+		<-clock.requested
+		cancel()
+
+		_, err := f.Result(t.Context())
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("retries each attempt with a timeout", func(t *testing.T) {
+		clock := newManualClock()
+
+		var calls atomic.Int64
+		fn := future.Apply(func(ctx context.Context) (int, error) {
+			if calls.Add(1) == 1 {
+				<-ctx.Done()
+				return 0, ctx.Err()
+			}
+
+			return 42, nil
+		},
+			future.Retry[int](2, backoff, future.WithClock(clock)),
+			future.Timeout[int](time.Second, future.WithClock(clock)),
+		)
+
+		f := future.Do(t.Context(), fn)
+		assert.Equal(t, time.Second, clock.Advance(t))
+		assert.Equal(t, 100*time.Millisecond, clock.Advance(t))
+
+		actual, err := f.Result(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 42, actual)
+	})
+}
+
+func TestHedge(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns first success", func(t *testing.T) {
+		clock := newManualClock()
+		cancelled := make(chan struct{})
+
+		var calls atomic.Int64
+		fn := future.Apply(func(ctx context.Context) (int, error) {
+			if calls.Add(1) == 1 {
+				<-ctx.Done()
+				close(cancelled)
+				return 0, ctx.Err()
+			}
+
+			return 42, nil
+		}, future.Hedge[int](100*time.Millisecond, 1, future.WithClock(clock)))
+
+		f := future.Do(t.Context(), fn)
+		assert.Equal(t, 100*time.Millisecond, clock.Advance(t))
+
+		actual, err := f.Result(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 42, actual)
+		<-cancelled
+	})
+
+	t.Run("starts next attempt on failure", func(t *testing.T) {
+		var calls atomic.Int64
+		fn := future.Apply(func(ctx context.Context) (int, error) {
+			if calls.Add(1) == 1 {
+				return 0, io.EOF
+			}
+
+			return 42, nil
+		}, future.Hedge[int](time.Hour, 1, future.WithClock(newManualClock())))
+
+		actual, err := future.Do(t.Context(), fn).Result(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 42, actual)
+	})
+
+	t.Run("returns all errors", func(t *testing.T) {
+		errOther := errors.New("other")
+
+		var calls atomic.Int64
+		fn := future.Apply(func(ctx context.Context) (int, error) {
+			if calls.Add(1) == 1 {
+				return 0, io.EOF
+			}
+
+			return 0, errOther
+		}, future.Hedge[int](time.Hour, 1, future.WithClock(newManualClock())))
+
+		_, err := future.Do(t.Context(), fn).Result(t.Context())
+		assert.ErrorIs(t, err, io.EOF)
+		assert.ErrorIs(t, err, errOther)
+		assert.EqualValues(t, 2, calls.Load())
+	})
+}